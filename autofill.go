@@ -0,0 +1,154 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// The autofill detail tokens that have a fixed place in the grammar.
+// Every other token the spec marks as an autofill token is a field name.
+var (
+	autofillToggles           = []string{"on", "off"}
+	autofillModes             = []string{"shipping", "billing"}
+	autofillContactTypes      = []string{"home", "work", "mobile", "fax", "pager"}
+	autofillCredentials       = []string{"webauthn"}
+	autofillContactFieldNames = []string{
+		"tel", "tel-country-code", "tel-national", "tel-area-code", "tel-local", "tel-local-prefix",
+		"tel-local-suffix", "tel-extension", "email", "impp",
+	}
+)
+
+// autofillFieldNames are used when the spec's autofill field names could not be found.
+var autofillFieldNames = []string{
+	"name", "honorific-prefix", "given-name", "additional-name", "family-name", "honorific-suffix", "nickname",
+	"username", "new-password", "current-password", "one-time-code", "organization-title", "organization",
+	"street-address", "address-line1", "address-line2", "address-line3", "address-level4", "address-level3",
+	"address-level2", "address-level1", "country", "country-name", "postal-code", "cc-name", "cc-given-name",
+	"cc-additional-name", "cc-family-name", "cc-number", "cc-exp", "cc-exp-month", "cc-exp-year", "cc-csc",
+	"cc-type", "transaction-currency", "transaction-amount", "language", "bday", "bday-day", "bday-month",
+	"bday-year", "sex", "url", "photo",
+}
+
+// AutofillGrammar describes the autofill detail tokens of autocomplete attributes.
+// A value is either a single toggle, or the following tokens in order: an optional section prefixed token,
+// an optional mode, then either a field name or an optional contact type followed by a contact field name,
+// and finally an optional credential token.
+type AutofillGrammar struct {
+	// Toggles can only be used on their own, i.e. on and off.
+	Toggles []string `json:"toggles"`
+	// SectionPrefix starts the token naming the section a field belongs to, i.e. section-.
+	SectionPrefix string `json:"section_prefix"`
+	// Modes tell which address a field is for, i.e. shipping and billing.
+	Modes []string `json:"modes"`
+	// FieldNames cannot be preceded by a contact type, e.g. name and postal-code.
+	FieldNames []string `json:"field_names"`
+	// ContactTypes tell which kind of contact a contact field is for, e.g. home and mobile.
+	ContactTypes []string `json:"contact_types"`
+	// ContactFieldNames can be preceded by a contact type, e.g. tel and email.
+	ContactFieldNames []string `json:"contact_field_names"`
+	// Credentials can follow the field name, i.e. webauthn.
+	Credentials []string `json:"credentials"`
+}
+
+// newAutofillGrammar returns the grammar for the given autofill tokens and section prefix as read from the spec,
+// falling back to the known field names when no tokens are given.
+func newAutofillGrammar(tokens map[string]struct{}, prefixes []string) *AutofillGrammar {
+	g := &AutofillGrammar{
+		Toggles:           autofillToggles,
+		SectionPrefix:     "section-",
+		Modes:             autofillModes,
+		FieldNames:        autofillFieldNames,
+		ContactTypes:      autofillContactTypes,
+		ContactFieldNames: autofillContactFieldNames,
+		Credentials:       autofillCredentials,
+	}
+	if len(prefixes) > 0 {
+		g.SectionPrefix = prefixes[0]
+	}
+	if len(tokens) == 0 {
+		return g.clone()
+	}
+
+	g.FieldNames = nil
+	g.ContactFieldNames = nil
+	for _, token := range slices.Sorted(maps.Keys(tokens)) {
+		switch {
+		case slices.Contains(autofillContactFieldNames, token):
+			g.ContactFieldNames = append(g.ContactFieldNames, token)
+		case slices.Contains(autofillToggles, token), slices.Contains(autofillModes, token),
+			slices.Contains(autofillContactTypes, token), slices.Contains(autofillCredentials, token):
+		default:
+			g.FieldNames = append(g.FieldNames, token)
+		}
+	}
+
+	return g.clone()
+}
+
+func (g *AutofillGrammar) clone() *AutofillGrammar {
+	if g == nil {
+		return nil
+	}
+
+	c := *g
+	c.Toggles = slices.Clone(g.Toggles)
+	c.Modes = slices.Clone(g.Modes)
+	c.FieldNames = slices.Clone(g.FieldNames)
+	c.ContactTypes = slices.Clone(g.ContactTypes)
+	c.ContactFieldNames = slices.Clone(g.ContactFieldNames)
+	c.Credentials = slices.Clone(g.Credentials)
+
+	return &c
+}
+
+// Validate checks that value is a valid autocomplete value, with its tokens in the order the grammar requires.
+// Tokens are matched ASCII case-insensitively.
+func (g *AutofillGrammar) Validate(value string) error {
+	tokens := strings.Fields(strings.ToLower(value))
+	if len(tokens) == 0 {
+		return errors.New("autocomplete value is empty")
+	}
+
+	if slices.Contains(g.Toggles, tokens[0]) {
+		if len(tokens) > 1 {
+			return fmt.Errorf("autocomplete token %q must be the only token", tokens[0])
+		}
+		return nil
+	}
+
+	i := 0
+	next := func(allowed []string) bool {
+		if i < len(tokens) && slices.Contains(allowed, tokens[i]) {
+			i++
+			return true
+		}
+		return false
+	}
+
+	if g.SectionPrefix != "" && len(tokens[i]) > len(g.SectionPrefix) && strings.HasPrefix(tokens[i], g.SectionPrefix) {
+		i++
+	}
+	next(g.Modes)
+	contact := next(g.ContactTypes)
+
+	if i == len(tokens) {
+		return errors.New("autocomplete value is missing a field name")
+	}
+	switch {
+	case next(g.ContactFieldNames):
+	case contact:
+		return fmt.Errorf("autocomplete token %q is not a contact field name that can follow %q", tokens[i], tokens[i-1])
+	case !next(g.FieldNames):
+		return fmt.Errorf("autocomplete token %q is not a field name or is out of order", tokens[i])
+	}
+
+	next(g.Credentials)
+	if i < len(tokens) {
+		return fmt.Errorf("autocomplete token %q is out of order", tokens[i])
+	}
+
+	return nil
+}
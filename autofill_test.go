@@ -0,0 +1,52 @@
+package spec
+
+import "testing"
+
+func TestAutofillGrammar_Validate(t *testing.T) {
+	g := newAutofillGrammar(nil, nil)
+
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "on"},
+		{value: "OFF"},
+		{value: "email"},
+		{value: "section-blue shipping street-address"},
+		{value: "billing cc-number"},
+		{value: "work email"},
+		{value: "section-a billing mobile tel webauthn"},
+		{value: "username webauthn"},
+		{value: "", wantErr: true},
+		{value: "on email", wantErr: true},
+		{value: "section-", wantErr: true},
+		{value: "shipping", wantErr: true},
+		{value: "home name", wantErr: true},
+		{value: "email shipping", wantErr: true},
+		{value: "shipping section-blue name", wantErr: true},
+		{value: "webauthn username", wantErr: true},
+		{value: "name email", wantErr: true},
+		{value: "favourite-colour", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if err := g.Validate(tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_newAutofillGrammar(t *testing.T) {
+	g := newAutofillGrammar(tokenSet("on", "off", "shipping", "home", "webauthn", "email", "tel", "name", "nickname"), []string{"section-"})
+
+	if len(g.FieldNames) != 2 || g.FieldNames[0] != "name" || g.FieldNames[1] != "nickname" {
+		t.Errorf("newAutofillGrammar() FieldNames = %v, want [name nickname]", g.FieldNames)
+	}
+	if len(g.ContactFieldNames) != 2 || g.ContactFieldNames[0] != "email" || g.ContactFieldNames[1] != "tel" {
+		t.Errorf("newAutofillGrammar() ContactFieldNames = %v, want [email tel]", g.ContactFieldNames)
+	}
+	if err := g.Validate("postal-code"); err == nil {
+		t.Error("Validate(postal-code) accepted a field name the spec did not list")
+	}
+}
@@ -0,0 +1,80 @@
+package spec
+
+import (
+	"slices"
+	"strings"
+)
+
+// AttributeOrder controls how Canonicalize orders the attributes of a Spec.
+type AttributeOrder int
+
+const (
+	// AttributeOrderName sorts attributes by name.
+	AttributeOrderName AttributeOrder = iota
+	// AttributeOrderSpec keeps attributes in the order the spec lists them.
+	AttributeOrderSpec
+)
+
+// headingTags are the heading elements that the spec defines in a single section alongside h1.
+var headingTags = []string{"h1", "h2", "h3", "h4", "h5", "h6"}
+
+// Canonicalize puts the spec into its canonical ordering so that regenerating it produces stable output.
+// Attributes are sorted by name, see CanonicalizeBy for keeping them in spec order.
+func (sp *Spec) Canonicalize() {
+	sp.CanonicalizeBy(AttributeOrderName)
+}
+
+// CanonicalizeBy puts the spec into its canonical ordering using the given attribute order.
+// Elements keep their spec order with h2-h6 grouped directly after h1.
+// Enum keywords need no handling here as encoding/json always writes map keys in sorted order.
+func (sp *Spec) CanonicalizeBy(order AttributeOrder) {
+	sp.Elements = groupHeadings(sp.Elements)
+
+	if order == AttributeOrderName {
+		sortAttributes(sp.Attributes)
+		for _, e := range sp.Elements {
+			sortAttributes(e.Attributes)
+		}
+	}
+}
+
+// groupHeadings moves h2-h6 to directly follow h1 in heading order.
+// If there is no h1 the headings are left where they are.
+func groupHeadings(elements []*Element) []*Element {
+	idx := slices.IndexFunc(elements, func(e *Element) bool {
+		return e.Tag == "h1"
+	})
+	if idx == -1 {
+		return elements
+	}
+
+	var headings []*Element
+	out := make([]*Element, 0, len(elements))
+	for _, e := range elements {
+		if slices.Contains(headingTags, e.Tag) {
+			headings = append(headings, e)
+			continue
+		}
+		out = append(out, e)
+	}
+
+	slices.SortStableFunc(headings, func(a, b *Element) int {
+		return strings.Compare(a.Tag, b.Tag)
+	})
+
+	// The group goes where h1 was, less any headings that appeared ahead of it.
+	before := 0
+	for _, e := range elements[:idx] {
+		if !slices.Contains(headingTags, e.Tag) {
+			before++
+		}
+	}
+
+	return slices.Insert(out, before, headings...)
+}
+
+func sortAttributes(attrs []Attribute) {
+	slices.SortStableFunc(attrs, func(a, b Attribute) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+}
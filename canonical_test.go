@@ -0,0 +1,43 @@
+package spec
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSpec_Canonicalize(t *testing.T) {
+	sp := &Spec{
+		Name: "HTML",
+		Attributes: []Attribute{
+			&AttributeTypeString{Name: "title"},
+			&AttributeTypeString{Name: "id"},
+		},
+		Elements: []*Element{
+			{Tag: "body"},
+			{Tag: "h1"},
+			{Tag: "p"},
+			{Tag: "canvas", Attributes: []Attribute{width, height}},
+			{Tag: "h3"},
+			{Tag: "h2"},
+		},
+	}
+
+	sp.Canonicalize()
+
+	var gotTags []string
+	for _, e := range sp.Elements {
+		gotTags = append(gotTags, e.Tag)
+	}
+	wantTags := []string{"body", "h1", "h2", "h3", "p", "canvas"}
+	if !slices.Equal(gotTags, wantTags) {
+		t.Errorf("Canonicalize() tags = %v, want %v", gotTags, wantTags)
+	}
+
+	if got := sp.Attributes[0].GetName(); got != "id" {
+		t.Errorf("Canonicalize() first global attribute = %v, want id", got)
+	}
+
+	if got := sp.Elements[5].Attributes[0].GetName(); got != "height" {
+		t.Errorf("Canonicalize() first canvas attribute = %v, want height", got)
+	}
+}
@@ -0,0 +1,118 @@
+package spec
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	validInteger = regexp.MustCompile(`^-?[0-9]+$`)
+	validFloat   = regexp.MustCompile(`^-?(?:[0-9]+(?:\.[0-9]+)?|\.[0-9]+)(?:[eE][+-]?[0-9]+)?$`)
+)
+
+// ValueChecker is implemented by attributes that can check if a value conforms to the spec.
+type ValueChecker interface {
+	// CheckValue returns why value is not a valid value for the attribute, or nil if it is.
+	CheckValue(value string) error
+}
+
+// CheckAttributeValue checks value against the attribute with the given name on the element with the given tag.
+// Values of attributes that are not known or that cannot be checked are always valid.
+func (sp *Spec) CheckAttributeValue(tag, name, value string) error {
+	attr, ok := sp.LookupAttribute(tag, name)
+	if !ok {
+		return nil
+	}
+
+	if checker, ok := attr.(ValueChecker); ok {
+		return checker.CheckValue(value)
+	}
+
+	return nil
+}
+
+// CheckValue checks that value is a valid integer within the attribute's constraints.
+func (a AttributeTypeNumber) CheckValue(value string) error {
+	if !validInteger.MatchString(value) {
+		return fmt.Errorf("attribute %q: %q is not a valid integer", a.Name, value)
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("attribute %q: %q is out of range", a.Name, value)
+	}
+
+	return checkBounds(a.Name, value, float64(n), floatPointer(a.Min), floatPointer(a.Max), a.NonNegative, a.Positive)
+}
+
+// CheckValue checks that value is a valid floating-point number within the attribute's constraints.
+func (a AttributeTypeFloat) CheckValue(value string) error {
+	if !validFloat.MatchString(value) {
+		return fmt.Errorf("attribute %q: %q is not a valid floating-point number", a.Name, value)
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("attribute %q: %q is out of range", a.Name, value)
+	}
+
+	return checkBounds(a.Name, value, n, a.Min, a.Max, a.NonNegative, a.Positive)
+}
+
+// CheckValue checks that value is one of the attribute's keywords, which are matched ASCII case-insensitively.
+func (a AttributeTypeEnum) CheckValue(value string) error {
+	if a.AllowCustom || (value == "" && a.AllowEmpty) {
+		return nil
+	}
+
+	for keyword := range a.Allowed {
+		if strings.EqualFold(keyword, value) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("attribute %q: %q is not an allowed keyword", a.Name, value)
+}
+
+func checkBounds(name, value string, n float64, minimum, maximum *float64, nonNegative, positive bool) error {
+	switch {
+	case positive && n <= 0:
+		return fmt.Errorf("attribute %q: %s must be greater than zero", name, value)
+	case nonNegative && n < 0:
+		return fmt.Errorf("attribute %q: %s must not be negative", name, value)
+	case minimum != nil && n < *minimum:
+		return fmt.Errorf("attribute %q: %s must be at least %v", name, value, *minimum)
+	case maximum != nil && n > *maximum:
+		return fmt.Errorf("attribute %q: %s must be at most %v", name, value, *maximum)
+	}
+
+	return nil
+}
+
+func floatPointer(n *int) *float64 {
+	if n == nil {
+		return nil
+	}
+
+	f := float64(*n)
+	return &f
+}
+
+// applyNumberConstraints sets the sign constraints of a number attribute from the text of its "Value" column in
+// the attribute index, such as "Valid non-negative integer greater than zero".
+func applyNumberConstraints(attr Attribute, value string) {
+	value = strings.ToLower(value)
+	nonNegative := strings.Contains(value, "non-negative")
+	positive := strings.Contains(value, "greater than zero") || strings.Contains(value, "positive")
+
+	switch a := attr.(type) {
+	case *AttributeTypeNumber:
+		a.NonNegative = a.NonNegative || nonNegative
+		a.Positive = a.Positive || positive
+	case *AttributeTypeFloat:
+		a.NonNegative = a.NonNegative || nonNegative
+		a.Positive = a.Positive || positive
+	}
+}
@@ -0,0 +1,77 @@
+package spec
+
+import "testing"
+
+func TestSpec_CheckAttributeValue(t *testing.T) {
+	sp := &Spec{
+		Name: "test",
+		Elements: []*Element{
+			{Tag: "col", Attributes: []Attribute{
+				&AttributeTypeNumber{Name: "span", Min: pointer(1), Max: pointer(1000), Positive: true},
+			}},
+			{Tag: "td", Attributes: []Attribute{
+				&AttributeTypeNumber{Name: "rowspan", Max: pointer(65534), NonNegative: true},
+			}},
+			{Tag: "meter", Attributes: []Attribute{
+				&AttributeTypeFloat{Name: "value", Min: pointer(0.0), Max: pointer(1.0)},
+				&AttributeTypeFloat{Name: "optimum"},
+			}},
+		},
+		Attributes: []Attribute{
+			&AttributeTypeEnum{Name: "dir", Allowed: map[string]struct{}{"ltr": {}, "rtl": {}, "auto": {}}},
+			&AttributeTypeEnum{Name: "translate", Allowed: map[string]struct{}{"yes": {}, "no": {}}, AllowEmpty: true},
+			&AttributeTypeNumber{Name: "tabindex"},
+			&AttributeTypeString{Name: "title"},
+		},
+	}
+
+	tests := []struct {
+		tag, name, value string
+		wantErr          bool
+	}{
+		{tag: "col", name: "span", value: "2"},
+		{tag: "col", name: "span", value: "1000"},
+		{tag: "col", name: "span", value: "0", wantErr: true},
+		{tag: "col", name: "span", value: "1001", wantErr: true},
+		{tag: "col", name: "span", value: "+2", wantErr: true},
+		{tag: "col", name: "span", value: " 2", wantErr: true},
+		{tag: "td", name: "rowspan", value: "0"},
+		{tag: "td", name: "rowspan", value: "-1", wantErr: true},
+		{tag: "td", name: "rowspan", value: "65535", wantErr: true},
+		{tag: "td", name: "tabindex", value: "-1"},
+		{tag: "td", name: "tabindex", value: "1.5", wantErr: true},
+		{tag: "td", name: "tabindex", value: "99999999999999999999", wantErr: true},
+		{tag: "meter", name: "value", value: "0.5"},
+		{tag: "meter", name: "value", value: ".5e0"},
+		{tag: "meter", name: "value", value: "1.5", wantErr: true},
+		{tag: "meter", name: "value", value: "5.", wantErr: true},
+		{tag: "meter", name: "optimum", value: "-3E2"},
+		{tag: "meter", name: "dir", value: "RTL"},
+		{tag: "meter", name: "dir", value: "up", wantErr: true},
+		{tag: "meter", name: "dir", value: "", wantErr: true},
+		{tag: "meter", name: "translate", value: ""},
+		{tag: "meter", name: "title", value: "anything"},
+		{tag: "meter", name: "unknown", value: "anything"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag+" "+tt.name+"="+tt.value, func(t *testing.T) {
+			if err := sp.CheckAttributeValue(tt.tag, tt.name, tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("CheckAttributeValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_applyNumberConstraints(t *testing.T) {
+	size := &AttributeTypeNumber{Name: "size"}
+	applyNumberConstraints(size, "Valid non-negative integer greater than zero")
+	if !size.NonNegative || !size.Positive {
+		t.Errorf("applyNumberConstraints() = %+v, want non-negative and positive", size)
+	}
+
+	maxlength := &AttributeTypeNumber{Name: "maxlength"}
+	applyNumberConstraints(maxlength, "Valid non-negative integer")
+	if !maxlength.NonNegative || maxlength.Positive {
+		t.Errorf("applyNumberConstraints() = %+v, want only non-negative", maxlength)
+	}
+}
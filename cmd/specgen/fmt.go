@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/go-htemel/spec"
+	"github.com/go-htemel/spec/format"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// runFmt implements `specgen fmt [flags] file.html...`, printing the formatted files or rewriting them with -w.
+func runFmt(args []string) {
+	fs := flag.NewFlagSet("fmt", flag.ExitOnError)
+	specPath := fs.String("spec", "specs/html.json", "Spec file to format against")
+	indent := fs.String("indent", "  ", "Indentation to use for each level of nesting")
+	sortAttrs := fs.Bool("sort-attrs", false, "Sort attributes by name with global attributes first")
+	write := fs.Bool("w", false, "Write the result back to the source file instead of stdout")
+	_ = fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		log.Fatal("fmt: no files given")
+	}
+
+	sp, err := loadSpec(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	formatter := format.New(sp, format.Options{
+		Indent:         *indent,
+		SortAttributes: *sortAttrs,
+	})
+
+	for _, path := range fs.Args() {
+		var out bytes.Buffer
+		if err = formatFile(formatter, path, &out); err != nil {
+			log.Fatal(err)
+		}
+
+		if *write {
+			if err = os.WriteFile(path, out.Bytes(), 0644); err != nil {
+				log.Fatal(err)
+			}
+			continue
+		}
+
+		if _, err = os.Stdout.Write(out.Bytes()); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func formatFile(formatter *format.Formatter, path string, out *bytes.Buffer) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	// Files without an html element are fragments, such as partial templates, and are formatted as body content.
+	if bytes.Contains(bytes.ToLower(src), []byte("<html")) {
+		doc, err := html.Parse(bytes.NewReader(src))
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		return formatter.Format(out, doc)
+	}
+
+	nodes, err := html.ParseFragment(bytes.NewReader(src), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	doc := &html.Node{Type: html.DocumentNode}
+	for _, n := range nodes {
+		doc.AppendChild(n)
+	}
+
+	return formatter.Format(out, doc)
+}
+
+func loadSpec(path string) (*spec.Spec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sp := &spec.Spec{}
+	if err = json.Unmarshal(b, sp); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return sp, nil
+}
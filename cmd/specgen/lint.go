@@ -0,0 +1,178 @@
+package main
+
+import (
+	"flag"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-htemel/spec/lint"
+)
+
+// runLint implements `specgen lint [flags] pattern...`, where patterns are files or globs that can use ** to match
+// any number of directories, e.g. ./templates/**/*.html.
+// Files ending in .tmpl, .gotmpl or .gohtml, or all files with -template, are linted as Go html/template files,
+// and files ending in .templ as templ files.
+// It exits with status 1 when any diagnostic is an error.
+func runLint(args []string) {
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	specPath := flags.String("spec", "specs/html.json", "Spec file to lint against")
+	configPath := flags.String("config", "", "Config file setting the severity of rules")
+	outFormat := flags.String("format", "text", "Output format: \"text\", \"json\" or \"sarif\"")
+	template := flags.Bool("template", false, "Lint all files as Go html/template files")
+	leftDelim := flags.String("left-delim", "{{", "Left delimiter of template actions")
+	rightDelim := flags.String("right-delim", "}}", "Right delimiter of template actions")
+	_ = flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		log.Fatal("lint: no files given")
+	}
+
+	sp, err := loadSpec(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var cfg lint.Config
+	if *configPath != "" {
+		if cfg, err = loadLintConfig(*configPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var paths []string
+	for _, pattern := range flags.Args() {
+		matches, err := expandGlob(pattern)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(matches) == 0 {
+			log.Fatalf("lint: %s matches no files", pattern)
+		}
+		paths = append(paths, matches...)
+	}
+
+	linter := lint.New(sp, cfg)
+	opts := lint.TemplateOptions{LeftDelim: *leftDelim, RightDelim: *rightDelim}
+
+	var diagnostics []lint.Diagnostic
+	for _, p := range paths {
+		src, err := os.ReadFile(p)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var found []lint.Diagnostic
+		switch {
+		case filepath.Ext(p) == ".templ":
+			found, err = linter.LintTempl(p, src)
+		case *template || isTemplate(p):
+			found, err = linter.LintTemplate(p, src, opts)
+		default:
+			found = linter.Lint(p, src)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		diagnostics = append(diagnostics, found...)
+	}
+
+	switch *outFormat {
+	case "text":
+		err = lint.WriteText(os.Stdout, diagnostics)
+	case "json":
+		err = lint.WriteJSON(os.Stdout, diagnostics)
+	case "sarif":
+		err = lint.WriteSARIF(os.Stdout, diagnostics)
+	default:
+		log.Fatalf("lint: unknown format %q", *outFormat)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if slices.ContainsFunc(diagnostics, func(d lint.Diagnostic) bool {
+		return d.Severity == lint.SeverityError
+	}) {
+		os.Exit(1)
+	}
+}
+
+func loadLintConfig(path string) (lint.Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return lint.Config{}, err
+	}
+	defer f.Close()
+
+	return lint.LoadConfig(f)
+}
+
+// isTemplate reports if the file extension is one used for Go templates.
+func isTemplate(p string) bool {
+	switch filepath.Ext(p) {
+	case ".tmpl", ".gotmpl", ".gohtml":
+		return true
+	}
+
+	return false
+}
+
+// expandGlob returns the files matching pattern in lexical order.
+// Patterns without ** are expanded with filepath.Glob, otherwise the directory before the first ** is walked and
+// each ** matches zero or more directories.
+func expandGlob(pattern string) ([]string, error) {
+	if !strings.Contains(pattern, "**") {
+		return filepath.Glob(pattern)
+	}
+
+	pattern = filepath.ToSlash(filepath.Clean(pattern))
+	root, _, _ := strings.Cut(pattern, "**")
+	root = strings.TrimSuffix(root, "/")
+	if root == "" {
+		root = "."
+	}
+
+	var out []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && matchGlob(strings.Split(pattern, "/"), strings.Split(filepath.ToSlash(p), "/")) {
+			out = append(out, p)
+		}
+		return nil
+	})
+
+	return out, err
+}
+
+// matchGlob reports if the path segments match the pattern segments, where a ** segment matches zero or more
+// path segments and the others are matched with path.Match.
+func matchGlob(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchGlob(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+		return false
+	}
+
+	return matchGlob(pattern[1:], segments[1:])
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/go-htemel/spec"
 )
@@ -16,17 +17,68 @@ type Config struct {
 	all          bool
 	htmlOnly     bool
 	htmlSpecSite string
+	attrOrder    string
+	overlay      string
+	manifest     string
+	obsolete     bool
+	compat       string
+	baselineYear int
+	baselineDate string
+	noUnstable   bool
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "fmt" {
+		runFmt(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		runLint(os.Args[2:])
+		return
+	}
+
 	cfg := Config{}
 
 	flag.StringVar(&cfg.outputDir, "output", "specs", "Directory to write spec files to")
 	flag.BoolVar(&cfg.all, "all", true, "Generate all spec files")
 	flag.BoolVar(&cfg.htmlOnly, "html", false, "Only generate HTML spec files")
 	flag.StringVar(&cfg.htmlSpecSite, "html-spec-site", "https://html.spec.whatwg.org/", "HTML spec site name")
+	flag.StringVar(&cfg.attrOrder, "attr-order", "name", "Order attributes by \"name\" or keep them in \"spec\" order")
+	flag.StringVar(&cfg.overlay, "overlay", "", "Overlay file to apply to the generated HTML spec")
+	flag.StringVar(&cfg.manifest, "custom-elements", "", "Custom elements manifest (custom-elements.json) to add elements from")
+	flag.BoolVar(&cfg.obsolete, "obsolete", false, "Keep obsolete elements and attributes in the generated spec")
+	flag.StringVar(&cfg.compat, "compat", "", "MDN browser compat data (data.json) to annotate elements and attributes with")
+	flag.StringVar(&cfg.baselineDate, "baseline-date", "", "Date (YYYY-MM-DD) to compute Baseline statuses as of, defaults to the newest browser release in the -compat data")
+	flag.IntVar(&cfg.baselineYear, "baseline-year", 0, "Remove elements and attributes that were not Baseline by the end of this year, requires -compat")
+	flag.BoolVar(&cfg.noUnstable, "exclude-unstable", false, "Remove elements and attributes that the spec flags as supported by fewer than two engines or in development")
 	flag.Parse()
 
+	var order spec.AttributeOrder
+	switch cfg.attrOrder {
+	case "name":
+		order = spec.AttributeOrderName
+	case "spec":
+		order = spec.AttributeOrderSpec
+	default:
+		log.Fatalf("unknown attribute order %q", cfg.attrOrder)
+	}
+
+	if cfg.baselineYear != 0 && cfg.compat == "" {
+		log.Fatal("-baseline-year requires -compat")
+	}
+
+	var asOf time.Time
+	if cfg.baselineDate != "" {
+		if cfg.compat == "" {
+			log.Fatal("-baseline-date requires -compat")
+		}
+
+		var err error
+		if asOf, err = time.Parse(time.DateOnly, cfg.baselineDate); err != nil {
+			log.Fatalf("-baseline-date: %v", err)
+		}
+	}
+
 	if _, err := os.Stat(cfg.outputDir); err != nil {
 		if err = os.MkdirAll(cfg.outputDir, 0755); err != nil {
 			log.Fatal(err)
@@ -46,11 +98,49 @@ func main() {
 			panic(err)
 		}
 
-		var out *spec.Spec
-		if out, err = spec.GenerateHTMLSpec(req.Body); err != nil {
+		out, warnings, err := spec.GenerateHTMLSpecWithWarnings(req.Body)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, w := range warnings {
+			log.Printf("warning: %s", w)
+		}
+
+		if !cfg.obsolete {
+			out.RemoveObsolete()
+		}
+
+		if cfg.noUnstable {
+			out.RemoveUnstable()
+		}
+
+		if err = out.ResolveURLs(cfg.htmlSpecSite); err != nil {
 			log.Fatal(err)
 		}
 
+		if cfg.compat != "" {
+			if err = applyCompat(out, cfg.compat, asOf); err != nil {
+				log.Fatal(err)
+			}
+			if cfg.baselineYear != 0 {
+				out.RemoveNotBaseline(cfg.baselineYear)
+			}
+		}
+
+		if cfg.manifest != "" {
+			if err = importManifest(out, cfg.manifest); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		if cfg.overlay != "" {
+			if err = applyOverlay(out, cfg.overlay); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		out.CanonicalizeBy(order)
+
 		jsonOut, err := json.MarshalIndent(out, "", "  ")
 		if err != nil {
 			log.Fatal(err)
@@ -61,3 +151,53 @@ func main() {
 		}
 	}
 }
+
+func applyOverlay(sp *spec.Spec, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	overlay, err := spec.LoadOverlay(f)
+	if err != nil {
+		return err
+	}
+
+	return sp.Apply(overlay)
+}
+
+// applyCompat computes Baseline statuses as of asOf, or of the newest browser release in the data when it is zero.
+func applyCompat(sp *spec.Spec, path string, asOf time.Time) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	data, err := spec.LoadCompatData(f)
+	if err != nil {
+		return err
+	}
+
+	if asOf.IsZero() {
+		asOf = data.LatestRelease()
+	}
+
+	return sp.ApplyCompat(data, asOf)
+}
+
+func importManifest(sp *spec.Spec, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	elements, err := spec.ImportCustomElementsManifest(f)
+	if err != nil {
+		return err
+	}
+
+	return sp.AddElements(elements...)
+}
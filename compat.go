@@ -0,0 +1,288 @@
+package spec
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// CoreBrowsers are the browsers that have to support a feature for it to be Baseline.
+var CoreBrowsers = []string{"chrome", "chrome_android", "edge", "firefox", "firefox_android", "safari", "safari_ios"}
+
+// baselineHighDelay is how long a feature has to be newly available before it is widely available.
+const baselineHighDelay = 30
+
+// BaselineStatus tells how widely a feature is supported across the CoreBrowsers.
+type BaselineStatus string
+
+const (
+	// BaselineLimited features are not supported by all core browsers yet.
+	BaselineLimited BaselineStatus = "limited"
+	// BaselineLow features are newly available, they are supported by all core browsers.
+	BaselineLow BaselineStatus = "low"
+	// BaselineHigh features are widely available, they have been supported by all core browsers for 30 months.
+	BaselineHigh BaselineStatus = "high"
+)
+
+// Compat holds the browser support of an element or attribute as read from MDN's browser compat data.
+type Compat struct {
+	// Support maps browser ids such as chrome and safari_ios to the version that added support, e.g. "114" or
+	// "≤79". Browsers that do not support the feature, or only behind a flag or prefix, are left out.
+	Support map[string]string `json:"support,omitempty"`
+	// Baseline is the Baseline status of the feature and BaselineDate the day it became newly available,
+	// e.g. 2023-04-11, which is empty for BaselineLimited features.
+	Baseline     BaselineStatus `json:"baseline"`
+	BaselineDate string         `json:"baseline_date,omitempty"`
+	Experimental bool           `json:"experimental,omitempty"`
+	Deprecated   bool           `json:"deprecated,omitempty"`
+}
+
+// BaselineYear returns the year the feature became newly available, or 0 if it is not Baseline.
+func (c *Compat) BaselineYear() int {
+	date, err := time.Parse(time.DateOnly, c.BaselineDate)
+	if err != nil {
+		return 0
+	}
+
+	return date.Year()
+}
+
+// CompatData is the HTML part of MDN's browser compat data, as published in the data.json file of the
+// @mdn/browser-compat-data package.
+type CompatData struct {
+	browsers         map[string]compatBrowser
+	elements         map[string]compatFeature
+	globalAttributes map[string]compatFeature
+}
+
+type compatBrowser struct {
+	Releases map[string]struct {
+		ReleaseDate string `json:"release_date"`
+		Status      string `json:"status"`
+	} `json:"releases"`
+}
+
+// compatFeature holds the __compat statement of a feature along with its subfeatures, such as the attributes of an
+// element.
+type compatFeature map[string]json.RawMessage
+
+type compatStatement struct {
+	Support map[string]compatSupport `json:"support"`
+	Status  struct {
+		Experimental bool `json:"experimental"`
+		Deprecated   bool `json:"deprecated"`
+	} `json:"status"`
+}
+
+// compatSupport is the support of a feature in a single browser, which the compat data writes as a single object or
+// as an array of them with the most relevant first.
+type compatSupport []compatSupportStatement
+
+type compatSupportStatement struct {
+	VersionAdded          json.RawMessage   `json:"version_added"`
+	VersionRemoved        json.RawMessage   `json:"version_removed"`
+	Prefix                string            `json:"prefix"`
+	AlternativeName       string            `json:"alternative_name"`
+	Flags                 []json.RawMessage `json:"flags"`
+	PartialImplementation bool              `json:"partial_implementation"`
+}
+
+func (s *compatSupport) UnmarshalJSON(b []byte) error {
+	if strings.HasPrefix(strings.TrimSpace(string(b)), "[") {
+		return json.Unmarshal(b, (*[]compatSupportStatement)(s))
+	}
+
+	var statement compatSupportStatement
+	if err := json.Unmarshal(b, &statement); err != nil {
+		return err
+	}
+	*s = compatSupport{statement}
+
+	return nil
+}
+
+// LoadCompatData reads MDN's browser compat data from r, only keeping the browsers and HTML features.
+func LoadCompatData(r io.Reader) (*CompatData, error) {
+	var tmp struct {
+		Browsers map[string]compatBrowser `json:"browsers"`
+		HTML     struct {
+			Elements         map[string]compatFeature `json:"elements"`
+			GlobalAttributes map[string]compatFeature `json:"global_attributes"`
+		} `json:"html"`
+	}
+
+	if err := json.NewDecoder(r).Decode(&tmp); err != nil {
+		return nil, err
+	}
+
+	return &CompatData{
+		browsers:         tmp.Browsers,
+		elements:         tmp.HTML.Elements,
+		globalAttributes: tmp.HTML.GlobalAttributes,
+	}, nil
+}
+
+// LatestRelease returns the release date of the newest version of the CoreBrowsers in the compat data, which is the
+// date the data is current as of. Releases that are planned or still in beta or nightly are left out.
+// It makes a reproducible date to pass to ApplyCompat, or the zero time if the data has no release dates.
+func (data *CompatData) LatestRelease() time.Time {
+	var latest time.Time
+	for _, browser := range CoreBrowsers {
+		for _, release := range data.browsers[browser].Releases {
+			switch release.Status {
+			case "planned", "beta", "nightly":
+				continue
+			}
+
+			date, err := time.Parse(time.DateOnly, release.ReleaseDate)
+			if err == nil && date.After(latest) {
+				latest = date
+			}
+		}
+	}
+
+	return latest
+}
+
+// ApplyCompat annotates the elements and attributes of the spec that are in the compat data with their browser
+// support. Baseline statuses are computed as of the given time, such as the one of LatestRelease, which keeps the
+// result the same however late it is computed.
+func (sp *Spec) ApplyCompat(data *CompatData, asOf time.Time) error {
+	for _, attr := range sp.Attributes {
+		if f, ok := data.globalAttributes[attr.GetName()]; ok {
+			compat, err := data.compat(f, asOf)
+			if err != nil {
+				return err
+			}
+			attr.Info().Compat = compat
+		}
+	}
+
+	for _, e := range sp.Elements {
+		f, ok := data.elements[e.Tag]
+		if !ok {
+			continue
+		}
+
+		var err error
+		if e.Compat, err = data.compat(f, asOf); err != nil {
+			return err
+		}
+
+		for _, attr := range e.Attributes {
+			raw, ok := f[attr.GetName()]
+			if !ok {
+				continue
+			}
+
+			var sub compatFeature
+			if err = json.Unmarshal(raw, &sub); err != nil {
+				return err
+			}
+			if attr.Info().Compat, err = data.compat(sub, asOf); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// compat returns the Compat of the feature, or nil if it has no __compat statement.
+func (data *CompatData) compat(f compatFeature, asOf time.Time) (*Compat, error) {
+	raw, ok := f["__compat"]
+	if !ok {
+		return nil, nil
+	}
+
+	var statement compatStatement
+	if err := json.Unmarshal(raw, &statement); err != nil {
+		return nil, err
+	}
+
+	c := &Compat{
+		Support:      make(map[string]string),
+		Baseline:     BaselineLimited,
+		Experimental: statement.Status.Experimental,
+		Deprecated:   statement.Status.Deprecated,
+	}
+	for browser, support := range statement.Support {
+		if version := support.version(); version != "" {
+			c.Support[browser] = version
+		}
+	}
+
+	var newest time.Time
+	for _, browser := range CoreBrowsers {
+		version, ok := c.Support[browser]
+		if !ok {
+			return c, nil
+		}
+
+		release, ok := data.browsers[browser].Releases[strings.TrimPrefix(version, "≤")]
+		if !ok {
+			return c, nil
+		}
+		date, err := time.Parse(time.DateOnly, release.ReleaseDate)
+		if err != nil {
+			return c, nil
+		}
+
+		if date.After(newest) {
+			newest = date
+		}
+	}
+
+	c.Baseline = BaselineLow
+	c.BaselineDate = newest.Format(time.DateOnly)
+	if !asOf.Before(newest.AddDate(0, baselineHighDelay, 0)) {
+		c.Baseline = BaselineHigh
+	}
+
+	return c, nil
+}
+
+// version returns the version of the browser that added full support for the feature without a flag or prefix,
+// or an empty string if there is none.
+func (s compatSupport) version() string {
+	for _, statement := range s {
+		if statement.Prefix != "" || statement.AlternativeName != "" || len(statement.Flags) > 0 ||
+			statement.PartialImplementation {
+			continue
+		}
+
+		var removed string
+		if json.Unmarshal(statement.VersionRemoved, &removed) == nil && removed != "" {
+			continue
+		}
+
+		var added string
+		if json.Unmarshal(statement.VersionAdded, &added) != nil || added == "preview" {
+			continue
+		}
+
+		return added
+	}
+
+	return ""
+}
+
+// RemoveNotBaseline removes the elements and attributes that did not become Baseline by the end of the given year.
+// Elements and attributes without compat data are kept.
+func (sp *Spec) RemoveNotBaseline(year int) {
+	keep := func(c *Compat) bool {
+		if c == nil {
+			return true
+		}
+
+		baseline := c.BaselineYear()
+		return baseline != 0 && baseline <= year
+	}
+
+	sp.Filter(func(e *Element) bool {
+		return keep(e.Compat)
+	}, func(attr Attribute) bool {
+		return keep(attr.Info().Compat)
+	})
+}
@@ -0,0 +1,136 @@
+package spec
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const compatJSON = `{
+	"__meta": {"version": "6.0.0"},
+	"browsers": {
+		"chrome": {"releases": {"1": {"release_date": "2008-12-11"}, "114": {"release_date": "2023-05-30"}, "999": {"release_date": "2099-01-01", "status": "planned"}}},
+		"chrome_android": {"releases": {"18": {"release_date": "2012-06-27"}, "114": {"release_date": "2023-05-30"}}},
+		"edge": {"releases": {"12": {"release_date": "2015-07-29"}, "114": {"release_date": "2023-06-02"}}},
+		"firefox": {"releases": {"1": {"release_date": "2004-11-09"}, "125": {"release_date": "2024-04-16"}}},
+		"firefox_android": {"releases": {"4": {"release_date": "2011-03-29"}, "125": {"release_date": "2024-04-16"}}},
+		"safari": {"releases": {"1": {"release_date": "2003-06-23"}, "17": {"release_date": "2023-09-18"}}},
+		"safari_ios": {"releases": {"1": {"release_date": "2007-06-29"}, "17": {"release_date": "2023-09-18"}}}
+	},
+	"html": {
+		"elements": {
+			"a": {
+				"__compat": {
+					"support": {
+						"chrome": {"version_added": "1"},
+						"chrome_android": {"version_added": "18"},
+						"edge": {"version_added": "12"},
+						"firefox": {"version_added": "1"},
+						"firefox_android": {"version_added": "4"},
+						"safari": {"version_added": "1"},
+						"safari_ios": {"version_added": "1"}
+					},
+					"status": {"experimental": false, "deprecated": false}
+				},
+				"ping": {
+					"__compat": {
+						"support": {
+							"chrome": {"version_added": "1"},
+							"chrome_android": {"version_added": "18"},
+							"edge": {"version_added": "≤79"},
+							"firefox": [{"version_added": "1", "flags": [{"type": "preference", "name": "browser.send_pings"}]}],
+							"firefox_android": {"version_added": false},
+							"safari": {"version_added": "1"},
+							"safari_ios": {"version_added": "1"}
+						},
+						"status": {"experimental": false, "deprecated": false}
+					}
+				}
+			}
+		},
+		"global_attributes": {
+			"popover": {
+				"__compat": {
+					"support": {
+						"chrome": {"version_added": "114"},
+						"chrome_android": {"version_added": "114"},
+						"edge": {"version_added": "114"},
+						"firefox": {"version_added": "125"},
+						"firefox_android": {"version_added": "125"},
+						"safari": [{"version_added": "17"}, {"version_added": "16", "flags": []}],
+						"safari_ios": {"version_added": "17"}
+					},
+					"status": {"experimental": false, "deprecated": false}
+				}
+			}
+		}
+	}
+}`
+
+func TestSpec_ApplyCompat(t *testing.T) {
+	data, err := LoadCompatData(strings.NewReader(compatJSON))
+	if err != nil {
+		t.Fatalf("LoadCompatData() error = %v", err)
+	}
+
+	sp := &Spec{
+		Attributes: []Attribute{&AttributeTypeEnum{Name: "popover"}, &AttributeTypeString{Name: "title"}},
+		Elements: []*Element{
+			{Tag: "a", Attributes: []Attribute{&AttributeTypeSST{Name: "ping"}, &AttributeTypeString{Name: "target"}}},
+			{Tag: "selectedcontent"},
+		},
+	}
+	if got, want := data.LatestRelease(), time.Date(2024, time.April, 16, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("LatestRelease() = %v, want %v", got, want)
+	}
+
+	if err = sp.ApplyCompat(data, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("ApplyCompat() error = %v", err)
+	}
+
+	a, _ := sp.Element("a")
+	ping, _ := a.Attribute("ping")
+	popover, _ := sp.LookupAttribute("a", "popover")
+
+	tests := []struct {
+		name         string
+		compat       *Compat
+		wantBaseline BaselineStatus
+		wantYear     int
+	}{
+		{name: "a", compat: a.Compat, wantBaseline: BaselineHigh, wantYear: 2015},
+		{name: "ping", compat: ping.Info().Compat, wantBaseline: BaselineLimited},
+		{name: "popover", compat: popover.Info().Compat, wantBaseline: BaselineLow, wantYear: 2024},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.compat == nil {
+				t.Fatal("ApplyCompat() did not set Compat")
+			}
+			if tt.compat.Baseline != tt.wantBaseline || tt.compat.BaselineYear() != tt.wantYear {
+				t.Errorf("ApplyCompat() = %v %v, want %v %v", tt.compat.Baseline, tt.compat.BaselineYear(),
+					tt.wantBaseline, tt.wantYear)
+			}
+		})
+	}
+
+	if got := ping.Info().Compat.Support; got["edge"] != "≤79" || got["firefox"] != "" || got["firefox_android"] != "" {
+		t.Errorf("ApplyCompat() ping support = %v", got)
+	}
+
+	sp.RemoveNotBaseline(2023)
+	if _, ok := sp.LookupAttribute("a", "popover"); ok {
+		t.Error("RemoveNotBaseline() kept popover")
+	}
+	if _, ok := a.Attribute("ping"); ok {
+		t.Error("RemoveNotBaseline() kept ping")
+	}
+	for _, tag := range []string{"a", "selectedcontent"} {
+		if _, ok := sp.Element(tag); !ok {
+			t.Errorf("RemoveNotBaseline() removed %s", tag)
+		}
+	}
+	if _, ok := sp.LookupAttribute("a", "title"); !ok {
+		t.Error("RemoveNotBaseline() removed title without compat data")
+	}
+}
@@ -0,0 +1,130 @@
+package spec
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	contextCategory = regexp.MustCompile(`(?i)where (.+?) (content|elements) (?:is|are) expected`)
+	contextAncestor = regexp.MustCompile("`([^`]+)` element ancestor")
+)
+
+// contextConditions start the parts of a context that restrict it further, e.g. "In a `noscript` element that is a
+// child of a `head` element", which are kept in the context's text only.
+var contextConditions = []string{" but only if", " that ", " containing ", " whose ", " if "}
+
+// Context is one of the contexts in which an element can be used, as listed in its definition.
+// Text holds the context as written in the spec, the other fields hold the parts of it that could be understood.
+type Context struct {
+	Text string `json:"text"`
+	// Category is set when the element can be used where content of that category is expected, e.g. "Flow content".
+	Category string `json:"category,omitempty"`
+	// Parents are the elements that the element can be a child of, e.g. ol, ul and menu for li.
+	Parents []string `json:"parents,omitempty"`
+	// Ancestors are elements that must be an ancestor of the element, e.g. map for area.
+	Ancestors []string `json:"ancestors,omitempty"`
+	// Before and After are the siblings that the element must come before or after, e.g. img for source in picture.
+	Before []string `json:"before,omitempty"`
+	After  []string `json:"after,omitempty"`
+	// Document is set when the element can be the document element.
+	Document bool `json:"document,omitempty"`
+}
+
+// parseContext reads a single context from its text with code spans in backticks, such as "As a child of a
+// `picture` element, before any `img` element.".
+// Conditions on attributes that precede a colon, such as "If the `itemprop` attribute is present: where flow
+// content is expected.", are skipped.
+func parseContext(text string) Context {
+	c := Context{Text: text}
+
+	main := strings.TrimSuffix(text, ".")
+	if idx := strings.LastIndex(main, ": "); idx != -1 {
+		main = main[idx+2:]
+	}
+
+	for _, match := range contextAncestor.FindAllStringSubmatch(main, -1) {
+		c.Ancestors = append(c.Ancestors, match[1])
+	}
+
+	if match := contextCategory.FindStringSubmatch(main); match != nil {
+		c.Category = strings.ToUpper(match[1][:1]) + match[1][1:] + " " + match[2]
+	}
+
+	if strings.Contains(main, "document element") {
+		c.Document = true
+	}
+
+	for _, condition := range contextConditions {
+		if idx := strings.Index(main, condition); idx != -1 {
+			main = main[:idx]
+		}
+	}
+	main = strings.ReplaceAll(main, "media element", "`audio` or `video` element")
+
+	// Code spans belong to the last keyword before them, e.g. "Before `dd` or `dt` elements inside `dl` elements".
+	var target *[]string
+	for _, word := range strings.Fields(main) {
+		switch strings.ToLower(strings.Trim(word, ",")) {
+		case "before":
+			target = &c.Before
+		case "after":
+			target = &c.After
+		case "inside", "in", "of":
+			target = &c.Parents
+		}
+
+		if spans := codeSpans(word); len(spans) > 0 && target != nil {
+			*target = append(*target, spans...)
+		}
+	}
+
+	return c
+}
+
+// CheckContext checks that the element with the given tag can be a child of the element with the parent tag,
+// or the document element when parent is empty.
+// Only contexts naming parent elements can be checked, so elements that are allowed wherever content of some
+// category is expected, and elements that are not known, are always allowed.
+func (sp *Spec) CheckContext(tag, parent string) error {
+	e, ok := sp.Element(tag)
+	if !ok || len(e.Contexts) == 0 {
+		return nil
+	}
+
+	var texts []string
+	for _, c := range e.Contexts {
+		switch {
+		case c.Category != "", len(c.Parents) == 0 && !c.Document:
+			return nil
+		case c.Document && parent == "", slices.Contains(c.Parents, parent):
+			return nil
+		}
+		texts = append(texts, c.Text)
+	}
+
+	if parent == "" {
+		return fmt.Errorf("element %q cannot be the document element, contexts: %s", tag, strings.Join(texts, " "))
+	}
+
+	return fmt.Errorf("element %q cannot be a child of %q, contexts: %s", tag, parent, strings.Join(texts, " "))
+}
+
+func cloneContexts(contexts []Context) []Context {
+	if contexts == nil {
+		return nil
+	}
+
+	out := make([]Context, 0, len(contexts))
+	for _, c := range contexts {
+		c.Parents = slices.Clone(c.Parents)
+		c.Ancestors = slices.Clone(c.Ancestors)
+		c.Before = slices.Clone(c.Before)
+		c.After = slices.Clone(c.After)
+		out = append(out, c)
+	}
+
+	return out
+}
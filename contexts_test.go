@@ -0,0 +1,101 @@
+package spec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseContext(t *testing.T) {
+	tests := []struct {
+		text string
+		want Context
+	}{
+		{
+			text: "Where flow content is expected.",
+			want: Context{Category: "Flow content"},
+		},
+		{
+			text: "Where script-supporting elements are expected.",
+			want: Context{Category: "Script-supporting elements"},
+		},
+		{
+			text: "Inside `ol` elements.",
+			want: Context{Parents: []string{"ol"}},
+		},
+		{
+			text: "As a child of a `picture` element, before any `img` element.",
+			want: Context{Parents: []string{"picture"}, Before: []string{"img"}},
+		},
+		{
+			text: "As a child of a media element, before any flow content or `track` elements.",
+			want: Context{Parents: []string{"audio", "video"}, Before: []string{"track"}},
+		},
+		{
+			text: "Before `dd` or `dt` elements inside `div` elements that are children of a `dl` element.",
+			want: Context{Parents: []string{"div"}, Before: []string{"dd", "dt"}},
+		},
+		{
+			text: "As a child of a `table` element, after any `caption`, `colgroup`, and `thead` elements, but only if there are no `tbody` elements that are children of the `table` element.",
+			want: Context{Parents: []string{"table"}, After: []string{"caption", "colgroup", "thead"}},
+		},
+		{
+			text: "Where phrasing content is expected, but only if there is a `map` element ancestor.",
+			want: Context{Category: "Phrasing content", Ancestors: []string{"map"}},
+		},
+		{
+			text: "If the `charset` attribute is present: in a `head` element.",
+			want: Context{Parents: []string{"head"}},
+		},
+		{
+			text: "As document's document element.",
+			want: Context{Document: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			tt.want.Text = tt.text
+			if got := parseContext(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseContext() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpec_CheckContext(t *testing.T) {
+	sp := &Spec{Elements: []*Element{
+		{Tag: "li", Contexts: []Context{
+			parseContext("Inside `ol` elements."),
+			parseContext("Inside `ul` elements."),
+			parseContext("Inside `menu` elements."),
+		}},
+		{Tag: "div", Contexts: []Context{
+			parseContext("Where flow content is expected."),
+			parseContext("As a child of a `dl` element."),
+		}},
+		{Tag: "html", Contexts: []Context{parseContext("As document's document element.")}},
+		{Tag: "b"},
+	}}
+
+	tests := []struct {
+		tag     string
+		parent  string
+		wantErr bool
+	}{
+		{tag: "li", parent: "ul"},
+		{tag: "li", parent: "menu"},
+		{tag: "li", parent: "div", wantErr: true},
+		{tag: "li", parent: "", wantErr: true},
+		{tag: "div", parent: "section"},
+		{tag: "html", parent: ""},
+		{tag: "html", parent: "body", wantErr: true},
+		{tag: "b", parent: "ul"},
+		{tag: "unknown", parent: "ul"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag+" in "+tt.parent, func(t *testing.T) {
+			if err := sp.CheckContext(tt.tag, tt.parent); (err != nil) != tt.wantErr {
+				t.Errorf("CheckContext() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CustomElementKind describes which kind of custom element an Element is.
+type CustomElementKind string
+
+const (
+	// CustomElementAutonomous elements are used by their own tag, e.g. <x-tooltip>.
+	CustomElementAutonomous CustomElementKind = "autonomous"
+	// CustomElementCustomizedBuiltIn elements extend a built-in element and are used through its is attribute,
+	// e.g. <button is="x-button">.
+	CustomElementCustomizedBuiltIn CustomElementKind = "customized-builtin"
+)
+
+// reservedCustomElementNames are names that match the custom element name production but belong to SVG and MathML.
+var reservedCustomElementNames = []string{
+	"annotation-xml",
+	"color-profile",
+	"font-face",
+	"font-face-src",
+	"font-face-uri",
+	"font-face-format",
+	"font-face-name",
+	"missing-glyph",
+}
+
+// IsValidCustomElementName reports if name is a valid custom element name.
+func IsValidCustomElementName(name string) bool {
+	return ValidateCustomElementName(name) == nil
+}
+
+// ValidateCustomElementName checks name against the valid custom element name rules, returning why it is invalid.
+// A valid name starts with an ASCII lower alpha, contains a hyphen, only contains PCENChar characters
+// and is not one of the reserved names such as font-face.
+func ValidateCustomElementName(name string) error {
+	if name == "" {
+		return errors.New("custom element name is empty")
+	}
+	if name[0] < 'a' || name[0] > 'z' {
+		return fmt.Errorf("custom element name %q must start with a lowercase ASCII letter", name)
+	}
+	if !strings.Contains(name, "-") {
+		return fmt.Errorf("custom element name %q must contain a hyphen", name)
+	}
+	for _, r := range name {
+		if !isPCENChar(r) {
+			return fmt.Errorf("custom element name %q contains the invalid character %q", name, r)
+		}
+	}
+	if slices.Contains(reservedCustomElementNames, name) {
+		return fmt.Errorf("custom element name %q is reserved", name)
+	}
+
+	return nil
+}
+
+// isPCENChar implements the PCENChar production from the custom elements section of the spec.
+func isPCENChar(r rune) bool {
+	switch {
+	case r == '-', r == '.', r == '_', r == 0xB7:
+		return true
+	case r >= '0' && r <= '9', r >= 'a' && r <= 'z':
+		return true
+	case r >= 0xC0 && r <= 0xD6,
+		r >= 0xD8 && r <= 0xF6,
+		r >= 0xF8 && r <= 0x37D,
+		r >= 0x37F && r <= 0x1FFF,
+		r >= 0x200C && r <= 0x200D,
+		r >= 0x203F && r <= 0x2040,
+		r >= 0x2070 && r <= 0x218F,
+		r >= 0x2C00 && r <= 0x2FEF,
+		r >= 0x3001 && r <= 0xD7FF,
+		r >= 0xF900 && r <= 0xFDCF,
+		r >= 0xFDF0 && r <= 0xFFFD,
+		r >= 0x10000 && r <= 0xEFFFF:
+		return true
+	}
+
+	return false
+}
+
+// customElementKind returns the kind of custom element e is, where elements with a hyphenated tag that don't say
+// what kind they are are autonomous custom elements.
+func customElementKind(e *Element) CustomElementKind {
+	if e.CustomElement == "" && strings.Contains(e.Tag, "-") {
+		return CustomElementAutonomous
+	}
+
+	return e.CustomElement
+}
+
+// validateElement checks that e can be added to the spec without changing it.
+func (sp *Spec) validateElement(e *Element) error {
+	kind := customElementKind(e)
+
+	switch kind {
+	case "":
+		if e.Extends != "" {
+			return fmt.Errorf("element %q extends %q but is not a customized built-in element", e.Tag, e.Extends)
+		}
+	case CustomElementAutonomous:
+		if e.Extends != "" {
+			return fmt.Errorf("autonomous custom element %q cannot extend %q", e.Tag, e.Extends)
+		}
+	case CustomElementCustomizedBuiltIn:
+		base, ok := sp.Element(e.Extends)
+		if !ok {
+			return fmt.Errorf("customized built-in element %q extends unknown element %q", e.Tag, e.Extends)
+		}
+		if base.CustomElement != "" {
+			return fmt.Errorf("customized built-in element %q must extend a built-in element, not %q", e.Tag, e.Extends)
+		}
+	default:
+		return fmt.Errorf("element %q has unknown custom element kind %q", e.Tag, e.CustomElement)
+	}
+
+	if kind != "" {
+		return ValidateCustomElementName(e.Tag)
+	}
+
+	return nil
+}
+
+// prepareElement fills in what is implied for a validated element.
+// Custom elements are always normal elements as far as the HTML syntax is concerned.
+func prepareElement(e *Element) {
+	e.CustomElement = customElementKind(e)
+	if e.CustomElement != "" && e.Kind == "" {
+		e.Kind = ElementKindNormal
+	}
+}
@@ -0,0 +1,100 @@
+package spec
+
+import "testing"
+
+func TestValidateCustomElementName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "x-tooltip"},
+		{name: "ds-button-2"},
+		{name: "math-α"},
+		{name: "emotion-😍"},
+		{name: "x-"},
+		{name: "tooltip", wantErr: true},
+		{name: "X-tooltip", wantErr: true},
+		{name: "x-Tooltip", wantErr: true},
+		{name: "1-tooltip", wantErr: true},
+		{name: "-tooltip", wantErr: true},
+		{name: "x tooltip-", wantErr: true},
+		{name: "font-face", wantErr: true},
+		{name: "annotation-xml", wantErr: true},
+		{name: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCustomElementName(tt.name); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCustomElementName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpec_AddElements(t *testing.T) {
+	tests := []struct {
+		name    string
+		element *Element
+		want    CustomElementKind
+		wantErr bool
+	}{
+		{
+			name:    "hyphenated tag becomes autonomous",
+			element: &Element{Tag: "x-tooltip"},
+			want:    CustomElementAutonomous,
+		},
+		{
+			name:    "customized built-in",
+			element: &Element{Tag: "x-button", CustomElement: CustomElementCustomizedBuiltIn, Extends: "button"},
+			want:    CustomElementCustomizedBuiltIn,
+		},
+		{
+			name:    "customized built-in of unknown element",
+			element: &Element{Tag: "x-thing", CustomElement: CustomElementCustomizedBuiltIn, Extends: "thing"},
+			wantErr: true,
+		},
+		{
+			name:    "reserved name",
+			element: &Element{Tag: "font-face"},
+			wantErr: true,
+		},
+		{
+			name:    "autonomous extending",
+			element: &Element{Tag: "x-button", CustomElement: CustomElementAutonomous, Extends: "button"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp := &Spec{Elements: []*Element{{Tag: "button"}}}
+
+			err := sp.AddElements(tt.element)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddElements() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if len(sp.Elements) != 1 {
+					t.Errorf("AddElements() added elements despite error")
+				}
+				return
+			}
+
+			if tt.element.CustomElement != tt.want {
+				t.Errorf("AddElements() CustomElement = %v, want %v", tt.element.CustomElement, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpec_AddElements_NoneAddedOnError(t *testing.T) {
+	sp := &Spec{Elements: []*Element{{Tag: "button"}}}
+	valid := &Element{Tag: "x-tooltip"}
+
+	if err := sp.AddElements(valid, &Element{Tag: "font-face"}); err == nil {
+		t.Fatal("AddElements() error = nil, want an error for the reserved name")
+	}
+
+	if len(sp.Elements) != 1 || valid.CustomElement != "" || valid.Kind != "" {
+		t.Errorf("AddElements() changed the spec or its elements despite error")
+	}
+}
@@ -0,0 +1,235 @@
+package spec
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// indexForeignElements are listed in the index of elements but are defined by the MathML and SVG specs.
+var indexForeignElements = []string{"math", "svg"}
+
+// indexedElement is a row of the spec's index of elements.
+// Rows such as the one for h1 to h6 describe more than one element.
+// The list columns keep the asterisks that mark entries with further restrictions, e.g. "phrasing*".
+type indexedElement struct {
+	tags        []string
+	description string
+	categories  []string
+	parents     []string
+	children    []string
+	attributes  []string
+	iface       string
+	pos         Position
+}
+
+// indexCategories are the names the index of elements uses for the content categories.
+var indexCategories = []struct {
+	category string
+	name     string
+}{
+	{CategoryMetadata, "metadata"},
+	{CategoryFlow, "flow"},
+	{CategorySectioning, "sectioning"},
+	{CategoryHeading, "heading"},
+	{CategoryPhrasing, "phrasing"},
+	{CategoryEmbedded, "embedded"},
+	{CategoryInteractive, "interactive"},
+	{CategoryPalpable, "palpable"},
+	{CategoryScriptSupporting, "script-supporting"},
+}
+
+// parseElementIndex reads the table of elements in the index, whose rows hold the elements, a description, their
+// categories, parents, children, attributes and DOM interface.
+// The list columns separate their entries with semicolons, e.g. "flow; phrasing; palpable".
+func parseElementIndex(table *html.Node, positions *positions) []indexedElement {
+	var out []indexedElement
+
+	for tr := range table.Descendants() {
+		if tr.Type != html.ElementNode || tr.Data != "tr" {
+			continue
+		}
+
+		var cells []*html.Node
+		for cell := range tr.ChildNodes() {
+			if cell.Type == html.ElementNode && (cell.Data == "th" || cell.Data == "td") {
+				cells = append(cells, cell)
+			}
+		}
+		if len(cells) < 7 || cells[0].Data != "th" {
+			continue
+		}
+
+		var tags []string
+		for _, tag := range codeSpans(gatherCodeText(cells[0])) {
+			if !slices.Contains(indexForeignElements, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		if len(tags) == 0 {
+			continue
+		}
+
+		pos, _ := positions.of(tr)
+		out = append(out, indexedElement{
+			tags:        tags,
+			description: strings.Join(strings.Fields(rawText(cells[1])), " "),
+			categories:  indexList(cells[2]),
+			parents:     indexList(cells[3]),
+			children:    indexList(cells[4]),
+			attributes:  indexList(cells[5]),
+			iface:       strings.TrimSuffix(strings.TrimSpace(rawText(cells[6])), "*"),
+			pos:         pos,
+		})
+	}
+
+	return out
+}
+
+// indexList splits the semicolon separated entries of a cell, dropping the entries that only say there are none.
+func indexList(cell *html.Node) []string {
+	var out []string
+	for _, entry := range strings.Split(rawText(cell), ";") {
+		entry = strings.Join(strings.Fields(entry), " ")
+		if entry != "" && entry != "none" && entry != "empty" {
+			out = append(out, entry)
+		}
+	}
+
+	return out
+}
+
+// indexCategoryDiff returns the content categories that the element belongs to unconditionally according to only
+// one of its section and its row of the index of elements, by the names the index uses.
+// Categories that the index marks with an asterisk only apply under conditions and are not compared.
+func indexCategoryDiff(e *Element, row indexedElement) (onlySection, onlyIndex []string) {
+	for _, c := range indexCategories {
+		if slices.Contains(row.categories, c.name+"*") {
+			continue
+		}
+
+		inIndex := slices.Contains(row.categories, c.name)
+		switch inSection := e.HasCategory(c.category); {
+		case inSection && !inIndex:
+			onlySection = append(onlySection, c.name)
+		case !inSection && inIndex:
+			onlyIndex = append(onlyIndex, c.name)
+		}
+	}
+
+	return onlySection, onlyIndex
+}
+
+// indexAttributeDiff returns the names of the element specific attributes that are only in one of the element and
+// its row of the index of elements.
+func indexAttributeDiff(e *Element, row indexedElement) (onlyElement, onlyIndex []string) {
+	var indexed []string
+	for _, name := range row.attributes {
+		if name = strings.TrimSuffix(name, "*"); name != "globals" {
+			indexed = append(indexed, name)
+		}
+	}
+
+	for _, attr := range e.Attributes {
+		if !slices.Contains(indexed, attr.GetName()) {
+			onlyElement = append(onlyElement, attr.GetName())
+		}
+	}
+	for _, name := range indexed {
+		if _, ok := e.Attribute(name); !ok {
+			onlyIndex = append(onlyIndex, name)
+		}
+	}
+
+	return onlyElement, onlyIndex
+}
+
+// reconcileElementIndex compares the elements found in their sections with the ones listed in the index,
+// returning a warning for each element that is only in one of them and for each differing DOM interface, set of
+// content categories and set of attributes.
+// The warnings about an element point to its heading, or to its row of the index for elements without a section.
+func reconcileElementIndex(elements []*Element, index []indexedElement, positions *positions) []Warning {
+	var warnings []Warning
+
+	indexed := make(map[string]indexedElement)
+	for _, row := range index {
+		for _, tag := range row.tags {
+			indexed[tag] = row
+		}
+	}
+
+	found := make(map[string]struct{})
+	for _, e := range elements {
+		// Elements without a URL were not found in a section of their own, such as h2-h6 when h1 was not found.
+		if e.URL == "" {
+			continue
+		}
+
+		found[e.Tag] = struct{}{}
+		pos, _ := positions.id(strings.TrimPrefix(e.URL, "#"))
+
+		row, ok := indexed[e.Tag]
+		if !ok {
+			warnings = append(warnings, Warning{
+				Message:  fmt.Sprintf("element %q has a section but is not in the index of elements", e.Tag),
+				Position: pos,
+			})
+			continue
+		}
+
+		if e.Interface != "" && row.iface != "" && e.Interface != row.iface {
+			warnings = append(warnings, Warning{
+				Message: fmt.Sprintf("element %q has interface %s but the index of elements lists %s", e.Tag,
+					e.Interface, row.iface),
+				Position: pos,
+			})
+		}
+
+		onlySection, onlyIndex := indexCategoryDiff(e, row)
+		if len(onlySection) > 0 {
+			warnings = append(warnings, Warning{
+				Message: fmt.Sprintf("element %q is %s content but the index of elements does not list it as such",
+					e.Tag, strings.Join(onlySection, ", ")),
+				Position: pos,
+			})
+		}
+		if len(onlyIndex) > 0 {
+			warnings = append(warnings, Warning{
+				Message: fmt.Sprintf("element %q is not %s content but the index of elements lists it as such",
+					e.Tag, strings.Join(onlyIndex, ", ")),
+				Position: pos,
+			})
+		}
+
+		onlyElement, onlyIndex := indexAttributeDiff(e, row)
+		if len(onlyElement) > 0 {
+			warnings = append(warnings, Warning{
+				Message: fmt.Sprintf("element %q has attributes %s that are not in the index of elements", e.Tag,
+					strings.Join(onlyElement, ", ")),
+				Position: pos,
+			})
+		}
+		if len(onlyIndex) > 0 {
+			warnings = append(warnings, Warning{
+				Message: fmt.Sprintf("element %q is missing attributes %s that the index of elements lists", e.Tag,
+					strings.Join(onlyIndex, ", ")),
+				Position: pos,
+			})
+		}
+	}
+
+	for _, row := range index {
+		for _, tag := range row.tags {
+			if _, ok := found[tag]; !ok {
+				warnings = append(warnings, Warning{
+					Message:  fmt.Sprintf("element %q is in the index of elements but its section was not found", tag),
+					Position: row.pos,
+				})
+			}
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,316 @@
+// Package format pretty prints html.Node trees, using a Spec to decide which elements are laid out as blocks.
+package format
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/go-htemel/spec"
+	"github.com/go-htemel/spec/internal/htmltext"
+	"golang.org/x/net/html"
+)
+
+// preserveTags have their content written exactly as parsed, as do elements with the raw text kinds.
+// The parser reads the content of iframe, noembed, noframes and noscript as text, which must not be escaped either.
+var preserveTags = []string{
+	"pre", "textarea", "script", "style", "listing", "xmp", "plaintext", "iframe", "noembed", "noframes", "noscript",
+}
+
+// phrasingFallback is used to classify elements when the spec was generated without element categories.
+var phrasingFallback = []string{
+	"a", "abbr", "area", "audio", "b", "bdi", "bdo", "br", "button", "canvas", "cite", "code", "data", "datalist",
+	"del", "dfn", "em", "embed", "i", "iframe", "img", "input", "ins", "kbd", "label", "link", "map", "mark", "math",
+	"meta", "meter", "noscript", "object", "output", "picture", "progress", "q", "ruby", "s", "samp", "script",
+	"select", "selectedcontent", "slot", "small", "span", "strong", "sub", "sup", "svg", "template", "textarea",
+	"time", "u", "var", "video", "wbr",
+}
+
+// Options controls the layout produced by a Formatter.
+type Options struct {
+	// Indent is written once per level of nesting, it defaults to two spaces.
+	Indent string
+	// SortAttributes orders attributes by name with global attributes before element specific ones.
+	SortAttributes bool
+}
+
+// Formatter lays out html.Node trees with block elements on their own lines and inline content kept together.
+type Formatter struct {
+	spec     *spec.Spec
+	elements map[string]*spec.Element
+	opts     Options
+}
+
+// New returns a Formatter for documents conforming to sp.
+func New(sp *spec.Spec, opts Options) *Formatter {
+	if opts.Indent == "" {
+		opts.Indent = "  "
+	}
+
+	elements := make(map[string]*spec.Element, len(sp.Elements))
+	for _, e := range sp.Elements {
+		elements[e.Tag] = e
+	}
+
+	return &Formatter{
+		spec:     sp,
+		elements: elements,
+		opts:     opts,
+	}
+}
+
+// Format writes the formatted form of n to w.
+func (f *Formatter) Format(w io.Writer, n *html.Node) error {
+	bw := bufio.NewWriter(w)
+
+	if n.Type == html.DocumentNode {
+		f.blockChildren(bw, n, 0)
+	} else {
+		f.block(bw, n, 0)
+	}
+
+	return bw.Flush()
+}
+
+// blockChildren writes the children of n, putting each block child and each run of inline content on its own line.
+func (f *Formatter) blockChildren(w *bufio.Writer, n *html.Node, depth int) {
+	var run []*html.Node
+	flush := func() {
+		if line := strings.TrimSpace(f.inline(run)); line != "" {
+			f.indent(w, depth)
+			w.WriteString(line + "\n")
+		}
+		run = run[:0]
+	}
+
+	for child := range n.ChildNodes() {
+		if f.isInline(child) {
+			run = append(run, child)
+			continue
+		}
+
+		flush()
+		f.block(w, child, depth)
+	}
+	flush()
+}
+
+func (f *Formatter) block(w *bufio.Writer, n *html.Node, depth int) {
+	switch n.Type {
+	case html.DoctypeNode:
+		f.indent(w, depth)
+		w.WriteString("<!DOCTYPE " + n.Data + ">\n")
+		return
+	case html.CommentNode:
+		f.indent(w, depth)
+		w.WriteString("<!--" + n.Data + "-->\n")
+		return
+	case html.TextNode:
+		if text := strings.TrimSpace(htmltext.CollapseWhitespace(n.Data)); text != "" {
+			f.indent(w, depth)
+			w.WriteString(escapeText(text) + "\n")
+		}
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	f.indent(w, depth)
+
+	if f.preserved(n) {
+		f.renderPreserved(w, n)
+		w.WriteString("\n")
+		return
+	}
+
+	w.WriteString(f.startTag(n))
+	if f.void(n) {
+		w.WriteString("\n")
+		return
+	}
+
+	if !f.hasBlockChild(n) {
+		w.WriteString(strings.TrimSpace(f.inline(slices.Collect(n.ChildNodes()))))
+		w.WriteString(endTag(n) + "\n")
+		return
+	}
+
+	w.WriteString("\n")
+	f.blockChildren(w, n, depth+1)
+	f.indent(w, depth)
+	w.WriteString(endTag(n) + "\n")
+}
+
+// inline returns nodes written on a single line with their whitespace collapsed.
+func (f *Formatter) inline(nodes []*html.Node) string {
+	var b strings.Builder
+
+	for _, n := range nodes {
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(escapeText(htmltext.CollapseWhitespace(n.Data)))
+		case html.CommentNode:
+			b.WriteString("<!--" + n.Data + "-->")
+		case html.ElementNode:
+			if f.preserved(n) {
+				bw := bufio.NewWriter(&b)
+				f.renderPreserved(bw, n)
+				bw.Flush()
+				continue
+			}
+
+			b.WriteString(f.startTag(n))
+			if f.void(n) {
+				continue
+			}
+			b.WriteString(f.inline(slices.Collect(n.ChildNodes())))
+			b.WriteString(endTag(n))
+		}
+	}
+
+	return b.String()
+}
+
+// isInline reports if n is laid out as part of the line it appears in.
+func (f *Formatter) isInline(n *html.Node) bool {
+	switch n.Type {
+	case html.TextNode, html.CommentNode:
+		return true
+	case html.ElementNode:
+		return f.phrasing(n) && !f.hasBlockChild(n)
+	}
+
+	return false
+}
+
+// hasBlockChild reports if any child of n has to go on its own line.
+func (f *Formatter) hasBlockChild(n *html.Node) bool {
+	if f.preserved(n) {
+		return false
+	}
+
+	for child := range n.ChildNodes() {
+		if !f.isInline(child) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// phrasing reports if n is phrasing content, which is kept on the line of its siblings so no whitespace is added
+// between them. All other elements, such as li and option, go on their own lines.
+func (f *Formatter) phrasing(n *html.Node) bool {
+	e, ok := f.element(n)
+	if !ok {
+		// Unknown and foreign elements stay inline as adding whitespace around them could change how they render.
+		return true
+	}
+	if len(e.Categories) == 0 {
+		return slices.Contains(phrasingFallback, e.Tag)
+	}
+
+	return e.HasCategory(spec.CategoryPhrasing)
+}
+
+func (f *Formatter) void(n *html.Node) bool {
+	e, ok := f.element(n)
+	return ok && (e.Void || e.Kind == spec.ElementKindVoid)
+}
+
+func (f *Formatter) preserved(n *html.Node) bool {
+	if e, ok := f.element(n); ok && e.Kind == spec.ElementKindRawText {
+		return true
+	}
+
+	return n.Type == html.ElementNode && n.Namespace == "" && slices.Contains(preserveTags, n.Data)
+}
+
+func (f *Formatter) element(n *html.Node) (*spec.Element, bool) {
+	if n.Type != html.ElementNode || n.Namespace != "" {
+		return nil, false
+	}
+
+	e, ok := f.elements[n.Data]
+	return e, ok
+}
+
+// renderPreserved writes n without touching its content.
+func (f *Formatter) renderPreserved(w *bufio.Writer, n *html.Node) {
+	// A shallow copy lets the attributes be sorted without changing the tree being formatted.
+	c := *n
+	c.Attr = f.attributes(n)
+	html.Render(w, &c)
+}
+
+func (f *Formatter) startTag(n *html.Node) string {
+	var b strings.Builder
+
+	b.WriteString("<" + n.Data)
+	for _, attr := range f.attributes(n) {
+		b.WriteString(" ")
+		if attr.Namespace != "" {
+			b.WriteString(attr.Namespace + ":")
+		}
+		b.WriteString(attr.Key + `="` + escapeAttr(attr.Val) + `"`)
+	}
+	b.WriteString(">")
+
+	return b.String()
+}
+
+func (f *Formatter) attributes(n *html.Node) []html.Attribute {
+	if !f.opts.SortAttributes {
+		return n.Attr
+	}
+
+	attrs := slices.Clone(n.Attr)
+	slices.SortStableFunc(attrs, func(a, b html.Attribute) int {
+		ga, gb := f.global(n, a), f.global(n, b)
+		switch {
+		case ga && !gb:
+			return -1
+		case !ga && gb:
+			return 1
+		}
+
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return attrs
+}
+
+// global reports if attr is one of the spec's global attributes rather than specific to n.
+func (f *Formatter) global(n *html.Node, attr html.Attribute) bool {
+	if attr.Namespace != "" {
+		return false
+	}
+	if e, ok := f.element(n); ok {
+		if _, ok = e.Attribute(attr.Key); ok {
+			return false
+		}
+	}
+
+	_, ok := f.spec.LookupAttribute("", attr.Key)
+	return ok
+}
+
+func (f *Formatter) indent(w *bufio.Writer, depth int) {
+	for range depth {
+		w.WriteString(f.opts.Indent)
+	}
+}
+
+func endTag(n *html.Node) string {
+	return "</" + n.Data + ">"
+}
+
+func escapeText(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func escapeAttr(s string) string {
+	return strings.NewReplacer("&", "&amp;", `"`, "&quot;").Replace(s)
+}
@@ -0,0 +1,124 @@
+package format
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-htemel/spec"
+	"golang.org/x/net/html"
+)
+
+func testSpec() *spec.Spec {
+	phrasing := []string{spec.CategoryFlow, spec.CategoryPhrasing}
+	flow := []string{spec.CategoryFlow}
+
+	return &spec.Spec{
+		Name: "HTML",
+		Attributes: []spec.Attribute{
+			&spec.AttributeTypeString{Name: "class"},
+			&spec.AttributeTypeString{Name: "id"},
+			&spec.AttributeTypeString{Name: "title"},
+		},
+		Elements: []*spec.Element{
+			{Tag: "html"},
+			{Tag: "head"},
+			{Tag: "title", Text: true, Categories: []string{spec.CategoryMetadata}},
+			{Tag: "body", Text: true},
+			{Tag: "div", Text: true, Categories: flow},
+			{Tag: "p", Text: true, Categories: flow},
+			{Tag: "pre", Text: true, Categories: flow},
+			{Tag: "ul", Categories: flow},
+			{Tag: "h2", Categories: flow},
+			{Tag: "li", Text: true},
+			{Tag: "b", Text: true, Categories: phrasing},
+			{Tag: "br", Void: true, Categories: phrasing},
+			{
+				Tag:        "a",
+				Text:       true,
+				Categories: phrasing,
+				Attributes: []spec.Attribute{&spec.AttributeTypeString{Name: "href"}},
+			},
+		},
+	}
+}
+
+func TestFormatter_Format(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		in   string
+		want string
+	}{
+		{
+			name: "document",
+			in:   "<!DOCTYPE html><html><head><title>Hi</title></head><body><div><p>Some <b>bold</b>\n   text<br>here</p><ul><li>one</li><li><a href=\"/\">two</a></li></ul></div></body></html>",
+			want: `<!DOCTYPE html>
+<html>
+  <head>
+    <title>Hi</title>
+  </head>
+  <body>
+    <div>
+      <p>Some <b>bold</b> text<br>here</p>
+      <ul>
+        <li>one</li>
+        <li><a href="/">two</a></li>
+      </ul>
+    </div>
+  </body>
+</html>
+`,
+		},
+		{
+			name: "preserve and mixed content",
+			in:   "<body><div>intro<p>para</p><pre>\n  keep\n    this</pre>outro</div></body>",
+			want: `<html>
+  <head></head>
+  <body>
+    <div>
+      intro
+      <p>para</p>
+      <pre>  keep
+    this</pre>
+      outro
+    </div>
+  </body>
+</html>
+`,
+		},
+		{
+			name: "phrasing siblings",
+			in:   "<body><h2><a>one</a><b>two</b></h2><ul><li>a</li></ul></body>",
+			want: "<html>\n  <head></head>\n  <body>\n    <h2><a>one</a><b>two</b></h2>\n    <ul>\n      <li>a</li>\n    </ul>\n  </body>\n</html>\n",
+		},
+		{
+			name: "raw text",
+			in:   `<body><p>a<noscript><img src=x></noscript><iframe><b>c</b></iframe></p></body>`,
+			want: "<html>\n  <head></head>\n  <body>\n    <p>a<noscript><img src=x></noscript><iframe><b>c</b></iframe></p>\n  </body>\n</html>\n",
+		},
+		{
+			name: "sorted attributes",
+			opts: Options{Indent: "\t", SortAttributes: true},
+			in:   `<body><a title="t" href="/x" id="y" class="z">link</a></body>`,
+			want: "<html>\n\t<head></head>\n\t<body><a class=\"z\" id=\"y\" title=\"t\" href=\"/x\">link</a></body>\n</html>\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(tt.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			if err = New(testSpec(), tt.opts).Format(&buf, doc); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Format() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package spec
 import (
 	"errors"
 	"io"
+	"maps"
 	"slices"
 	"strconv"
 	"strings"
@@ -47,8 +48,9 @@ func GlobalAttributes() []Attribute {
 			Description: "The autofocus content attribute allows the author to indicate that an element is to be focused as soon as the page is loaded, allowing the user to just start typing without having to manually focus the main element.",
 		},
 		&AttributeTypeSST{
-			Name:        "class",
-			Description: "When specified on HTML elements, the class attribute must have a value that is a set of space-separated tokens representing the various classes that the element belongs to.",
+			Name:          "class",
+			Description:   "When specified on HTML elements, the class attribute must have a value that is a set of space-separated tokens representing the various classes that the element belongs to.",
+			CaseSensitive: true,
 		},
 		&AttributeTypeEnum{
 			Name:        "contenteditable",
@@ -130,20 +132,26 @@ func GlobalAttributes() []Attribute {
 			Description: "The itemid attribute, if specified, must have a value that is a valid URL potentially surrounded by spaces.",
 		},
 		&AttributeTypeSST{
-			Name:        "itemprop",
-			Description: "The itemprop attribute, if specified, must have a value that is an unordered set of unique space-separated tokens none of which are identical to another token, representing the names of the name-value pairs that it adds. The attribute's value must have at least one token.",
+			Name:          "itemprop",
+			Description:   "The itemprop attribute, if specified, must have a value that is an unordered set of unique space-separated tokens none of which are identical to another token, representing the names of the name-value pairs that it adds. The attribute's value must have at least one token.",
+			Unique:        true,
+			CaseSensitive: true,
 		},
 		&AttributeTypeSST{
-			Name:        "itemref",
-			Description: "The itemref attribute, if specified, must have a value that is an unordered set of unique space-separated tokens none of which are identical to another token and consisting of IDs of elements in the same tree.",
+			Name:          "itemref",
+			Description:   "The itemref attribute, if specified, must have a value that is an unordered set of unique space-separated tokens none of which are identical to another token and consisting of IDs of elements in the same tree.",
+			Unique:        true,
+			CaseSensitive: true,
 		},
 		&AttributeTypeBool{
 			Name:        "itemscope",
 			Description: "Every HTML element may have an itemscope attribute specified. The itemscope attribute is a boolean attribute.",
 		},
 		&AttributeTypeSST{
-			Name:        "itemtype",
-			Description: "The itemtype attribute, if specified, must have a value that is an unordered set of unique space-separated tokens, none of which are identical to another token and each of which is a valid URL string that is an absolute URL, and all of which are defined to use the same vocabulary. The attribute's value must have at least one token.",
+			Name:          "itemtype",
+			Description:   "The itemtype attribute, if specified, must have a value that is an unordered set of unique space-separated tokens, none of which are identical to another token and each of which is a valid URL string that is an absolute URL, and all of which are defined to use the same vocabulary. The attribute's value must have at least one token.",
+			Unique:        true,
+			CaseSensitive: true,
 		},
 		&AttributeTypeString{
 			Name:        "lang",
@@ -209,8 +217,9 @@ func GlobalAttributes() []Attribute {
 
 // Some re-used attributes
 var fetchPriority = &AttributeTypeEnum{
-	Name:        "fetchpriority",
-	Description: "Sets the priority for fetches initiated by the element",
+	Name:           "fetchpriority",
+	Description:    "Sets the priority for fetches initiated by the element",
+	MissingDefault: "auto",
 	Allowed: map[string]struct{}{
 		"high": {},
 		"low":  {},
@@ -230,8 +239,9 @@ var height = &AttributeTypeNumber{
 	Description: "Vertical dimension",
 }
 var loading = &AttributeTypeEnum{
-	Name:        "loading",
-	Description: "Used when determining loading deferral",
+	Name:           "loading",
+	Description:    "Used when determining loading deferral",
+	MissingDefault: "eager",
 	Allowed: map[string]struct{}{
 		"lazy":  {},
 		"eager": {},
@@ -261,14 +271,33 @@ var href = &AttributeTypeString{
 var colspan = &AttributeTypeNumber{
 	Name:        "span",
 	Description: "Number of columns spanned by the element where the number is > 0 && <= 1000",
+	Min:         pointer(1),
+	Max:         pointer(1000),
+	Positive:    true,
 }
 var rowspan = &AttributeTypeNumber{
 	Name:        "rowspan",
 	Description: "Number of rows that the cell is to span where the number is > 0 && <= 65534",
+	Max:         pointer(65534),
+	NonNegative: true,
 }
 var headers = &AttributeTypeSST{
-	Name:        "headers",
-	Description: "The header cells for this cell",
+	Name:          "headers",
+	Description:   "The header cells for this cell",
+	Unique:        true,
+	CaseSensitive: true,
+}
+var autocomplete = &AttributeTypeSST{
+	Name:        "autocomplete",
+	Description: "Hint for form autofill feature",
+}
+var blocking = &AttributeTypeSST{
+	Name:        "blocking",
+	Description: "Whether the element is potentially render-blocking",
+	Allowed: map[string]struct{}{
+		"render": {},
+	},
+	Unique: true,
 }
 
 // More statically defined things because parsing this from the whatwg spec is painful.
@@ -326,6 +355,11 @@ func linkAttr() []Attribute {
 		&AttributeTypeSST{
 			Name:        "sizes",
 			Description: "Sizes of the icons (for rel=\"icon\")",
+			Allowed: map[string]struct{}{
+				"any": {},
+			},
+			Pattern: iconSizesPattern,
+			Unique:  true,
 		},
 		&AttributeTypeString{
 			Name:        "imagesrcset",
@@ -339,13 +373,7 @@ func linkAttr() []Attribute {
 			Name:        "as",
 			Description: "Potential destination for a preload request (for rel=\"preload\" and rel=\"modulepreload\")",
 		},
-		&AttributeTypeEnum{
-			Name:        "blocking",
-			Description: "Whether the element is potentially render-blocking",
-			Allowed: map[string]struct{}{
-				"render": {},
-			},
-		},
+		blocking,
 		&AttributeTypeString{
 			Name:        "color",
 			Description: "Color to use when customizing a site's icon (for rel=\"mask-icon\")",
@@ -398,10 +426,7 @@ func styleAttr() []Attribute {
 			Name:        "media",
 			Description: "Applicable media",
 		},
-		&AttributeTypeString{
-			Name:        "blocking",
-			Description: "Whether the element is potentially render-blocking",
-		},
+		blocking,
 	}
 }
 
@@ -592,8 +617,9 @@ func imgAttr() []Attribute {
 		height,
 		referrerPolicy,
 		&AttributeTypeEnum{
-			Name:        "decoding",
-			Description: "Decoding hint to use when processing this image for presentation",
+			Name:           "decoding",
+			Description:    "Decoding hint to use when processing this image for presentation",
+			MissingDefault: "auto",
 			Allowed: map[string]struct{}{
 				"sync":  {},
 				"async": {},
@@ -748,8 +774,9 @@ func audioAttr() []Attribute {
 func trackAttr() []Attribute {
 	return []Attribute{
 		&AttributeTypeEnum{
-			Name:        "kind",
-			Description: "The type of text track",
+			Name:           "kind",
+			Description:    "The type of text track",
+			MissingDefault: "subtitles",
 			Allowed: map[string]struct{}{
 				"subtitles":    {},
 				"captions":     {},
@@ -794,8 +821,9 @@ func areaAttr() []Attribute {
 			Description: "Coordinates for the shape to be created in an image map",
 		},
 		&AttributeTypeEnum{
-			Name:        "shape",
-			Description: "The kind of shape to be created in an image map",
+			Name:           "shape",
+			Description:    "The kind of shape to be created in an image map",
+			MissingDefault: "rect",
 			Allowed: map[string]struct{}{
 				"circle":  {},
 				"default": {},
@@ -871,8 +899,9 @@ func formAttr() []Attribute {
 			Description: "URL to use for form submission",
 		},
 		&AttributeTypeEnum{
-			Name:        "autocomplete",
-			Description: "Default setting for autofill feature for controls in the form",
+			Name:           "autocomplete",
+			Description:    "Default setting for autofill feature for controls in the form",
+			MissingDefault: "on",
 			Allowed: map[string]struct{}{
 				"on":  {},
 				"off": {},
@@ -883,8 +912,9 @@ func formAttr() []Attribute {
 			Description: "Entry list encoding type to use for form submission",
 		},
 		&AttributeTypeEnum{
-			Name:        "method",
-			Description: "Variant to use for form submission",
+			Name:           "method",
+			Description:    "Variant to use for form submission",
+			MissingDefault: "get",
 			Allowed: map[string]struct{}{
 				"get":    {},
 				"post":   {},
@@ -919,6 +949,16 @@ func labelAttr() []Attribute {
 	}
 }
 
+// inputDateTimeSyntaxes are used by the min and max attributes of input, which take a date or time for the
+// date and time input types and a number for the number and range types.
+var inputDateTimeSyntaxes = []DateTimeSyntax{
+	DateTimeDate,
+	DateTimeMonth,
+	DateTimeWeek,
+	DateTimeTime,
+	DateTimeLocalDateTime,
+}
+
 func inputAttr() []Attribute {
 	return []Attribute{
 		&AttributeTypeString{
@@ -933,17 +973,15 @@ func inputAttr() []Attribute {
 			Name:        "alt",
 			Description: "Replacement text for use when images are not available",
 		},
-		&AttributeTypeSST{
-			Name:        "autocomplete",
-			Description: "Hint for form autofill feature",
-		},
+		autocomplete,
 		&AttributeTypeBool{
 			Name:        "checked",
-			Description: "Hint for form autofill feature",
+			Description: "Whether the control is checked",
 		},
 		&AttributeTypeEnum{
-			Name:        "colorspace",
-			Description: "The color space of the serialized color",
+			Name:           "colorspace",
+			Description:    "The color space of the serialized color",
+			MissingDefault: "limited-srgb",
 			Allowed: map[string]struct{}{
 				"limited-srgb": {},
 				"display-p3":   {},
@@ -991,17 +1029,21 @@ func inputAttr() []Attribute {
 			Name:        "list",
 			Description: "List of autocomplete options",
 		},
-		&AttributeTypeNumber{
+		&AttributeTypeDateTime{
 			Name:        "max",
 			Description: "Maximum value",
+			Syntaxes:    inputDateTimeSyntaxes,
+			AllowNumber: true,
 		},
 		&AttributeTypeNumber{
 			Name:        "maxlength",
 			Description: "Maximum length of value",
 		},
-		&AttributeTypeNumber{
+		&AttributeTypeDateTime{
 			Name:        "min",
 			Description: "Minimum value",
+			Syntaxes:    inputDateTimeSyntaxes,
+			AllowNumber: true,
 		},
 		&AttributeTypeNumber{
 			Name:        "minlength",
@@ -1028,8 +1070,9 @@ func inputAttr() []Attribute {
 			Description: "Targets a popover element to toggle, show, or hide",
 		},
 		&AttributeTypeEnum{
-			Name:        "popovertargetaction",
-			Description: "Indicates whether a targeted popover element is to be toggled, shown, or hidden",
+			Name:           "popovertargetaction",
+			Description:    "Indicates whether a targeted popover element is to be toggled, shown, or hidden",
+			MissingDefault: "toggle",
 			Allowed: map[string]struct{}{
 				"toggle": {},
 				"show":   {},
@@ -1054,8 +1097,9 @@ func inputAttr() []Attribute {
 			Description: "Granularity to be matched by the form control's value",
 		},
 		&AttributeTypeEnum{
-			Name:        "type",
-			Description: "Type of form control",
+			Name:           "type",
+			Description:    "Type of form control",
+			MissingDefault: "text",
 			Allowed: map[string]struct{}{
 				"hidden":         {},
 				"text":           {},
@@ -1150,8 +1194,9 @@ func buttonAttr() []Attribute {
 			Description: "Targets a popover element to toggle, show, or hide",
 		},
 		&AttributeTypeEnum{
-			Name:        "popovertargetaction",
-			Description: "Indicates whether a targeted popover element is to be toggled, shown, or hidden",
+			Name:           "popovertargetaction",
+			Description:    "Indicates whether a targeted popover element is to be toggled, shown, or hidden",
+			MissingDefault: "toggle",
 			Allowed: map[string]struct{}{
 				"toggle": {},
 				"show":   {},
@@ -1176,10 +1221,7 @@ func buttonAttr() []Attribute {
 
 func selectAttr() []Attribute {
 	return []Attribute{
-		&AttributeTypeString{
-			Name:        "autocomplete",
-			Description: "Hint for form autofill feature",
-		},
+		autocomplete,
 		&AttributeTypeBool{
 			Name:        "disabled",
 			Description: "Whether the form control is disabled",
@@ -1243,10 +1285,7 @@ func optionAttr() []Attribute {
 
 func textareaAttr() []Attribute {
 	return []Attribute{
-		&AttributeTypeString{
-			Name:        "autocomplete",
-			Description: "Hint for form autofill feature",
-		},
+		autocomplete,
 		&AttributeTypeNumber{
 			Name:        "cols",
 			Description: "Maximum number of characters per line",
@@ -1292,8 +1331,9 @@ func textareaAttr() []Attribute {
 			Description: "Number of lines to show",
 		},
 		&AttributeTypeEnum{
-			Name:        "wrap",
-			Description: "How the value of the form control is to be wrapped for form submission",
+			Name:           "wrap",
+			Description:    "How the value of the form control is to be wrapped for form submission",
+			MissingDefault: "soft",
 			Allowed: map[string]struct{}{
 				"soft": {},
 				"hard": {},
@@ -1411,9 +1451,10 @@ func dialogAttr() []Attribute {
 
 func scriptAttr() []Attribute {
 	return []Attribute{
-		&AttributeTypeString{
+		&AttributeTypeMIME{
 			Name:        "type",
 			Description: "Type of script",
+			Keywords:    []string{"module", "importmap"},
 		},
 		src,
 		&AttributeTypeBool{
@@ -1428,13 +1469,7 @@ func scriptAttr() []Attribute {
 			Name:        "defer",
 			Description: "Defer script execution",
 		},
-		&AttributeTypeEnum{
-			Name:        "blocking",
-			Description: "Whether the element is potentially render-blocking",
-			Allowed: map[string]struct{}{
-				"render": {},
-			},
-		},
+		blocking,
 		crossorigin,
 		referrerPolicy,
 		&AttributeTypeString{
@@ -1542,7 +1577,48 @@ var attrFuncs = map[string]func() []Attribute{
 	"canvas":     canvasAttr,
 }
 
+// parseElementDefinition fills in e from the entries of the dl.element block that defines it.
+func parseElementDefinition(e *Element, dl *html.Node) {
+	defs := definitions(dl)
+
+	for _, dd := range defs["Categories"] {
+		category := strings.TrimSuffix(gatherText(dd), ".")
+		if category != "" && category != "None" {
+			e.Categories = append(e.Categories, category)
+		}
+	}
+
+	for _, dd := range defs["Contexts in which this element can be used"] {
+		if text := gatherCodeText(dd); text != "" {
+			e.Contexts = append(e.Contexts, parseContext(text))
+		}
+	}
+
+	var omission []string
+	for _, dd := range defs["Tag omission in text/html"] {
+		omission = append(omission, gatherCodeText(dd))
+	}
+	e.TagOmission = parseTagOmission(strings.Join(omission, " "))
+
+	for _, dd := range defs["DOM interface"] {
+		if name, reflections := parseInterface(rawText(dd)); name != "" {
+			e.Interface = name
+			e.Reflections = reflections
+			break
+		}
+	}
+}
+
+// GenerateHTMLSpec generates the spec from the single page version of the HTML standard.
 func GenerateHTMLSpec(closer io.ReadCloser) (*Spec, error) {
+	sp, _, err := GenerateHTMLSpecWithWarnings(closer)
+	return sp, err
+}
+
+// GenerateHTMLSpecWithWarnings generates the spec like GenerateHTMLSpec, also returning the problems found in the
+// document that did not stop the generation, such as elements that are only in one of the element sections and
+// the index of elements, or whose categories or attributes differ between the two.
+func GenerateHTMLSpecWithWarnings(closer io.ReadCloser) (*Spec, []Warning, error) {
 	p := NewSpecParser(HTML)
 
 	// Add the defined global attributes
@@ -1555,36 +1631,48 @@ func GenerateHTMLSpec(closer io.ReadCloser) (*Spec, error) {
 		}
 	}(closer)
 
-	doc, err := html.Parse(closer)
+	src, err := io.ReadAll(closer)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
+	doc, positions, err := parseWithPositions(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	p.positions = positions
+
 	var body *html.Node
 	var ok bool
 	if body, ok = findTag(doc, "body"); !ok {
-		return nil, errors.New("could not find body")
+		return nil, nil, errors.New("could not find body")
 	}
 
-	start := false
-	end := false
+	section := ""
+	var semantics *html.Node
+	inKinds := false
+	var kinds map[string]ElementKind
+	values := make(valueIndex)
+	nonConforming := false
+	var obsolete []obsoleteFeature
+	var index []indexedElement
+	vocab := newVocabularies()
 	for child := range body.ChildNodes() {
-		if child.Data == "h2" {
-			if start {
-				end = true
-				start = false
-			}
-
-			if _, ok = getIDIndex(child.Attr, "id", "semantics"); ok {
-				start = true
-			}
+		// The introductory text of an element is the run of paragraphs after its definition, which ends at the first
+		// other element such as a note, an example or the next heading.
+		if p.active && p.descParsed && child.Type == html.ElementNode && !isIntroParagraph(child) {
+			p.Reset()
 		}
 
-		if end {
-			break
+		if child.Data == "h2" {
+			section, _ = getAttribute(child.Attr, "id")
+			if section == "semantics" && semantics == nil {
+				semantics = child
+			}
 		}
 
-		if start {
+		switch section {
+		case "semantics":
 			// Look for H4 elements and then check to see if their ID contains the term "element".
 			// If so, then check the `code` tag for the text value.
 			if child.Data == "h4" {
@@ -1592,40 +1680,111 @@ func GenerateHTMLSpec(closer io.ReadCloser) (*Spec, error) {
 				if id, ok = getAttribute(child.Attr, "id"); ok {
 					if strings.Contains(id, "the-") && strings.Contains(id, "-element") {
 						var tagNode *html.Node
-						if tagNode, ok = findTag(child, "code"); ok {
-							p.Activate(tagNode.FirstChild.Data)
+						if tagNode, ok = findTag(child, "code"); !ok {
+							p.warnf(child, "h4 id %q has no code child", id)
+						} else if tag := strings.TrimSpace(rawText(tagNode)); tag == "" {
+							p.warnf(tagNode, "h4 id %q has an empty code child", id)
+						} else {
+							p.Activate(tag)
+							p.currElement.URL = "#" + id
 						}
 					}
 				}
 			}
 
-			if child.Data == "p" {
-				if p.active && !p.descParsed {
-					p.currElement.Description = gatherText(child, nil)
-					p.descParsed = true
-					p.Reset()
+			// The dl.element block holding the element's definition sits between its heading and description.
+			if child.Data == "dl" && hasClass(child.Attr, "element") {
+				if p.active {
+					parseElementDefinition(p.currElement, child)
+				} else {
+					p.warnf(child, "element definition does not follow the heading of an element")
+				}
+			}
+
+			if p.active && isIntroParagraph(child) {
+				p.addParagraph(child)
+			}
+		case "syntax":
+			// The kinds of elements are listed in the first dl of the "Elements" section.
+			if child.Data == "h4" {
+				id, _ := getAttribute(child.Attr, "id")
+				inKinds = id == "elements-2"
+			}
+
+			if child.Data == "dl" && inKinds {
+				kinds = parseElementKinds(child)
+				inKinds = false
+			}
+		case "links":
+			// The table of link types lists the rel keywords along with the elements they can be used on.
+			if child.Data == "table" {
+				if th, ok := findTag(child, "th"); ok && strings.Contains(rawText(th), "Link type") {
+					vocab.linkTypes = parseLinkTypes(child)
+				}
+			}
+		case "obsolete":
+			// Only the non-conforming features are listed as obsolete elements and attributes, the features that are
+			// obsolete but conforming are still valid HTML.
+			if child.Data == "h3" {
+				id, _ := getAttribute(child.Attr, "id")
+				nonConforming = id == "non-conforming-features"
+			}
+
+			if child.Data == "dl" && nonConforming {
+				obsolete = append(obsolete, parseObsoleteFeatures(child)...)
+			}
+		case "index":
+			// The value column of the attribute index tables tells which microsyntax each attribute uses,
+			// which in turn tells which attributes hold URLs, scripts and styles.
+			for table := range withDescendants(child) {
+				if table.Type != html.ElementNode || table.Data != "table" {
+					continue
+				}
+
+				caption, ok := findTag(table, "caption")
+				if !ok {
+					continue
+				}
+
+				// The event handler table's value column names them as event handler content attributes.
+				text := rawText(caption)
+				if strings.Contains(text, "List of attributes") ||
+					strings.Contains(text, "List of event handler content attributes") {
+					values.parseAttributeTable(table)
+				}
+
+				// The index of elements is used to check that no element section was missed and that the sections agree with it.
+				if strings.Contains(text, "List of elements") {
+					index = append(index, parseElementIndex(table, positions)...)
 				}
 			}
 		}
 	}
 
-	// Void Elements
-	isVoid := []string{
-		"area",
-		"base",
-		"br",
-		"col",
-		"embed",
-		"hr",
-		"img",
-		"input",
-		"link",
-		"meta",
-		"source",
-		"track",
-		"wbr",
+	if p.active && p.descParsed {
+		p.Reset()
+	}
+
+	// Without any element the spec's structure has changed in a way the walk above no longer understands.
+	if semantics == nil {
+		return nil, nil, errors.New("could not find the semantics section")
+	}
+	if len(p.Spec.Elements) == 0 {
+		pos, _ := positions.of(semantics)
+		return nil, nil, &PositionError{
+			Position: pos,
+			Err:      errors.New("the semantics section has no element definitions"),
+		}
 	}
 
+	if len(kinds) == 0 {
+		kinds = fallbackKinds
+	}
+
+	vocab.collectKeywords(body)
+	stabilities := parseStabilities(body)
+	p.Spec.LinkTypes = vocab.linkTypes
+
 	disallowText := []string{
 		"picture",
 		"source",
@@ -1658,11 +1817,44 @@ func GenerateHTMLSpec(closer io.ReadCloser) (*Spec, error) {
 		"html",
 	}
 
+	for i, attr := range p.Spec.Attributes {
+		if value, ok := values.value("", attr.GetName()); ok {
+			attr = typedAttribute(attr, value)
+			applyNumberConstraints(attr, value)
+			applyTokenConstraints(attr, value)
+			p.Spec.Attributes[i] = attr
+		}
+		attr.Info().Security = securityClass(values, "", attr)
+		attr.Info().URL = values.href("", attr.GetName())
+		attr.Info().Stability = stabilities[strings.TrimPrefix(attr.Info().URL, "#")]
+	}
+
 	for _, e := range p.Spec.Elements {
 		if fn, ok := attrFuncs[e.Tag]; ok {
-			e.Attributes = append(e.Attributes, fn()...)
+			for _, attr := range fn() {
+				// Attributes such as href are shared between elements, so they are cloned before being classified.
+				attr = cloneAttribute(attr)
+				if value, ok := values.value(e.Tag, attr.GetName()); ok {
+					attr = typedAttribute(attr, value)
+					applyNumberConstraints(attr, value)
+					applyTokenConstraints(attr, value)
+				}
+				vocab.apply(e.Tag, attr)
+				attr.Info().Security = securityClass(values, e.Tag, attr)
+				attr.Info().URL = values.href(e.Tag, attr.GetName())
+				attr.Info().Stability = stabilities[strings.TrimPrefix(attr.Info().URL, "#")]
+				e.Attributes = append(e.Attributes, attr)
+			}
+		}
+
+		e.Stability = stabilities[strings.TrimPrefix(e.URL, "#")]
+
+		e.Kind = ElementKindNormal
+		if kind, ok := kinds[e.Tag]; ok {
+			e.Kind = kind
 		}
-		if slices.Contains(isVoid, e.Tag) {
+
+		if e.Kind == ElementKindVoid {
 			e.Void = true
 		} else {
 			if !slices.Contains(disallowText, e.Tag) {
@@ -1671,15 +1863,44 @@ func GenerateHTMLSpec(closer io.ReadCloser) (*Spec, error) {
 		}
 	}
 
-	// Manually add h2-h6
+	// Manually add h2-h6 as the spec defines them all in a single section, directly after h1 when it was found.
+	// They are added after the elements are filled in and get their own copy of what the section says about h1.
+	headings := make([]*Element, 0, 5)
+	h1 := &Element{Kind: ElementKindNormal, Text: true}
+	idx := slices.IndexFunc(p.Spec.Elements, func(e *Element) bool {
+		return e.Tag == "h1"
+	})
+	if idx != -1 {
+		h1 = p.Spec.Elements[idx]
+	}
 	for i := 2; i < 7; i++ {
-		e := &Element{
-			Tag:         "h" + strconv.Itoa(i),
-			Description: "These elements represent headings for their sections.",
-		}
+		headings = append(headings, &Element{
+			Tag:             "h" + strconv.Itoa(i),
+			Description:     "These elements represent headings for their sections.",
+			DescriptionText: "These elements represent headings for their sections.",
+			URL:             h1.URL,
+			Categories:      slices.Clone(h1.Categories),
+			Contexts:        cloneContexts(h1.Contexts),
+			TagOmission:     h1.TagOmission.clone(),
+			Interface:       h1.Interface,
+			Reflections:     maps.Clone(h1.Reflections),
+			Kind:            h1.Kind,
+			Void:            h1.Void,
+			Text:            h1.Text,
+			Stability:       h1.Stability,
+		})
+	}
+	if idx != -1 {
+		p.Spec.Elements = slices.Insert(p.Spec.Elements, idx+1, headings...)
+	} else {
+		p.Spec.Elements = append(p.Spec.Elements, headings...)
+	}
 
-		p.Spec.Elements = append(p.Spec.Elements, e)
+	if len(index) > 0 {
+		p.warnings = append(p.warnings, reconcileElementIndex(p.Spec.Elements, index, positions)...)
 	}
 
-	return p.Spec, nil
+	p.Spec.addObsolete(obsolete, values)
+
+	return p.Spec, p.warnings, nil
 }
@@ -2,7 +2,11 @@ package spec
 
 import (
 	"bytes"
+	"errors"
 	"io"
+	"maps"
+	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -14,7 +18,7 @@ func TestGenerateHTMLSpec(t *testing.T) {
 		<script></script>
 		<p></p>
 		<h2 id="skip-me"></h2>
-		<h2 id="semantics"></h2><h4><p><code>tag</code></p></h4><p>Good description</p><p>I shouldn't be in output</p>
+		<h2 id="semantics"></h2><h4 id="the-tag-element"><p><code>tag</code></p></h4><p>Good description</p><p>More <a href="#the-tag-element"><code>tag</code></a> description</p><p class="note">I shouldn't be in output</p>
 		<h2 id="parsing-should-stop"><h4><p><code>badtag</code></p></h4><p>Bad description</p></h2>
 	</body>
 </html>
@@ -37,7 +41,11 @@ func TestGenerateHTMLSpec(t *testing.T) {
 			want: &Spec{
 				Name: "HTML",
 				Elements: []*Element{
-					{Tag: "tag", Description: "Good description"},
+					{
+						Tag:             "tag",
+						Description:     "Good description\n\nMore [`tag`](#the-tag-element) description",
+						DescriptionText: "Good description\n\nMore tag description",
+					},
 				},
 			},
 			wantErr: false,
@@ -52,8 +60,9 @@ func TestGenerateHTMLSpec(t *testing.T) {
 				return
 			}
 
-			if len(got.Elements) != 1 {
-				t.Errorf("len(gotArray) = %d, want 1", len(got.Elements))
+			// h2-h6 are added after the parsed elements even without h1.
+			if len(got.Elements) != 6 {
+				t.Errorf("len(gotArray) = %d, want 6", len(got.Elements))
 				t.FailNow()
 			}
 
@@ -74,6 +83,585 @@ func TestGenerateHTMLSpec(t *testing.T) {
 			if gotDescription != wantDescription {
 				t.Errorf("GenerateHTMLSpec() Element.Description got = %v, want %v", gotDescription, wantDescription)
 			}
+
+			gotText := got.Elements[0].DescriptionText
+			wantText := tt.want.Elements[0].DescriptionText
+			if gotText != wantText {
+				t.Errorf("GenerateHTMLSpec() Element.DescriptionText got = %v, want %v", gotText, wantText)
+			}
+		})
+	}
+}
+
+func TestGenerateHTMLSpec_ElementDefinition(t *testing.T) {
+	htmlDoc := `
+<html>
+	<body>
+		<h2 id="semantics"></h2>
+		<h4 id="the-a-element"><span class="secno">4.5.1</span> The <dfn><code>a</code></dfn> element</h4>
+		<dl class="element">
+			<dt><a href="#concept-element-dom">DOM interface</a>:</dt>
+			<dd>
+<pre><code class="idl">[<a>Exposed</a>=Window]
+interface <dfn>HTMLAnchorElement</dfn> : <a>HTMLElement</a> {
+  [<a>HTMLConstructor</a>] constructor();
+
+  [<a>CEReactions</a>, <a>Reflect</a>] attribute DOMString <a>target</a>;
+  [<a>CEReactions</a>, <a>Reflect</a>=hreflang] attribute DOMString <a>hrefLang</a>;
+  [<a>SameObject</a>, <a>PutForwards</a>=<a>value</a>, <a>Reflect</a>="rel"] readonly attribute <a>DOMTokenList</a> <a>relList</a>;
+  [<a>CEReactions</a>] attribute DOMString <a>text</a>;
+};</code></pre>
+			</dd>
+		</dl>
+		<p>The a element represents a hyperlink.</p>
+		<h4 id="the-em-element"><code>em</code></h4>
+		<dl class="element">
+			<dt><a href="#concept-element-contexts">Contexts in which this element can be used</a>:</dt>
+			<dd>Where <a>phrasing content</a> is expected.</dd>
+			<dt>DOM interface:</dt>
+			<dd>Uses <code>HTMLElement</code>.</dd>
+		</dl>
+		<p>The em element represents stress emphasis of its contents.</p>
+		<h2 id="stop"></h2>
+	</body>
+</html>
+`
+
+	got, err := GenerateHTMLSpec(io.NopCloser(bytes.NewBufferString(htmlDoc)))
+	if err != nil {
+		t.Fatalf("GenerateHTMLSpec() error = %v", err)
+	}
+
+	a, ok := got.Element("a")
+	if !ok {
+		t.Fatal("GenerateHTMLSpec() did not find a")
+	}
+	if a.Interface != "HTMLAnchorElement" {
+		t.Errorf("GenerateHTMLSpec() a.Interface = %v, want HTMLAnchorElement", a.Interface)
+	}
+
+	wantReflections := map[string]Reflection{
+		"target":   {IDLName: "target", Type: "DOMString"},
+		"hreflang": {IDLName: "hrefLang", Type: "DOMString"},
+		"rel":      {IDLName: "relList", Type: "DOMTokenList"},
+	}
+	if !maps.Equal(a.Reflections, wantReflections) {
+		t.Errorf("GenerateHTMLSpec() a.Reflections = %v, want %v", a.Reflections, wantReflections)
+	}
+
+	em, _ := got.Element("em")
+	if em.Interface != "HTMLElement" || em.Reflections != nil {
+		t.Errorf("GenerateHTMLSpec() em.Interface = %v, em.Reflections = %v", em.Interface, em.Reflections)
+	}
+
+	wantContexts := []Context{{Text: "Where phrasing content is expected.", Category: CategoryPhrasing}}
+	if !reflect.DeepEqual(em.Contexts, wantContexts) {
+		t.Errorf("GenerateHTMLSpec() em.Contexts = %+v, want %+v", em.Contexts, wantContexts)
+	}
+}
+
+func TestGenerateHTMLSpec_Headings(t *testing.T) {
+	htmlDoc := `
+<html>
+	<body>
+		<h2 id="semantics"></h2>
+		<h4 id="the-h1,-h2,-h3,-h4,-h5,-and-h6-elements"><code>h1</code>, <code>h2</code>, <code>h3</code>, <code>h4</code>, <code>h5</code>, and <code>h6</code> elements</h4>
+		<dl class="element">
+			<dt>Categories:</dt>
+			<dd><a>Flow content</a>.</dd>
+			<dd><a>Heading content</a>.</dd>
+			<dt>Contexts in which this element can be used:</dt>
+			<dd>Where <a>flow content</a> is expected.</dd>
+			<dt>Tag omission in text/html:</dt>
+			<dd>Neither tag is omissible.</dd>
+			<dt>DOM interface:</dt>
+			<dd>Uses <code>HTMLHeadingElement</code>.</dd>
+		</dl>
+		<p>These elements represent headings for their sections.</p>
+		<h2 id="stop"></h2>
+	</body>
+</html>
+`
+
+	got, err := GenerateHTMLSpec(io.NopCloser(bytes.NewBufferString(htmlDoc)))
+	if err != nil {
+		t.Fatalf("GenerateHTMLSpec() error = %v", err)
+	}
+
+	h1, ok := got.Element("h1")
+	if !ok {
+		t.Fatal("GenerateHTMLSpec() did not find h1")
+	}
+	for _, tag := range []string{"h2", "h3", "h4", "h5", "h6"} {
+		h, ok := got.Element(tag)
+		if !ok {
+			t.Fatalf("GenerateHTMLSpec() did not find %s", tag)
+		}
+		if !h.Text || h.Void || h.Kind != ElementKindNormal {
+			t.Errorf("GenerateHTMLSpec() %s Text = %v, Void = %v, Kind = %v", tag, h.Text, h.Void, h.Kind)
+		}
+		if !reflect.DeepEqual(h.Categories, h1.Categories) || !reflect.DeepEqual(h.Contexts, h1.Contexts) ||
+			h.Interface != "HTMLHeadingElement" {
+			t.Errorf("GenerateHTMLSpec() %s = %+v, want the fields of h1 %+v", tag, h, h1)
+		}
+	}
+
+	h2, _ := got.Element("h2")
+	want := slices.Clone(h2.Categories)
+	wantContexts := cloneContexts(h2.Contexts)
+	h1.Categories[0] = "changed"
+	h1.Contexts[0].Text = "changed"
+	if !slices.Equal(h2.Categories, want) || !reflect.DeepEqual(h2.Contexts, wantContexts) {
+		t.Errorf("GenerateHTMLSpec() h2 shares its fields with h1, Categories = %v, Contexts = %+v", h2.Categories, h2.Contexts)
+	}
+}
+
+func TestGenerateHTMLSpec_ElementKinds(t *testing.T) {
+	htmlDoc := `
+<html>
+	<body>
+		<h2 id="semantics"></h2>
+		<h4 id="the-br-element"><code>br</code></h4><p>The br element represents a line break.</p>
+		<h4 id="the-script-element"><code>script</code></h4><p>The script element allows authors to include dynamic script.</p>
+		<h4 id="the-title-element"><code>title</code></h4><p>The title element represents the document's title.</p>
+		<h4 id="the-p-element"><code>p</code></h4><p>The p element represents a paragraph.</p>
+		<h2 id="syntax"></h2>
+		<h4 id="elements-2"><span class="secno">13.1.2</span> Elements</h4>
+		<p>There are six different kinds of elements:</p>
+		<dl>
+			<dt><dfn>Void elements</dfn></dt>
+			<dd><code><a>area</a></code>, <code><a>br</a></code></dd>
+			<dt><a>The <code>template</code> element</a></dt>
+			<dd><code><a>template</a></code></dd>
+			<dt><dfn>Raw text elements</dfn></dt>
+			<dd><code><a>script</a></code>, <code><a>style</a></code></dd>
+			<dt><dfn>Escapable raw text elements</dfn></dt>
+			<dd><code><a>textarea</a></code>, <code><a>title</a></code></dd>
+			<dt><dfn>Foreign elements</dfn></dt>
+			<dd>Elements from the MathML namespace and the SVG namespace.</dd>
+			<dt><dfn>Normal elements</dfn></dt>
+			<dd>All other allowed HTML elements are normal elements.</dd>
+		</dl>
+	</body>
+</html>
+`
+
+	got, err := GenerateHTMLSpec(io.NopCloser(bytes.NewBufferString(htmlDoc)))
+	if err != nil {
+		t.Fatalf("GenerateHTMLSpec() error = %v", err)
+	}
+
+	tests := []struct {
+		tag  string
+		want ElementKind
+	}{
+		{tag: "br", want: ElementKindVoid},
+		{tag: "script", want: ElementKindRawText},
+		{tag: "title", want: ElementKindEscapableRawText},
+		{tag: "p", want: ElementKindNormal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			e, ok := got.Element(tt.tag)
+			if !ok {
+				t.Fatalf("GenerateHTMLSpec() did not find %s", tt.tag)
+			}
+			if e.Kind != tt.want {
+				t.Errorf("GenerateHTMLSpec() %s.Kind = %v, want %v", tt.tag, e.Kind, tt.want)
+			}
+			if e.Void != (tt.want == ElementKindVoid) {
+				t.Errorf("GenerateHTMLSpec() %s.Void = %v", tt.tag, e.Void)
+			}
+		})
+	}
+}
+
+func TestGenerateHTMLSpec_AttributeSecurity(t *testing.T) {
+	htmlDoc := `
+<html>
+	<body>
+		<h2 id="semantics"></h2>
+		<h4 id="the-a-element"><code>a</code></h4><p>The a element represents a hyperlink.</p>
+		<h4 id="the-img-element"><code>img</code></h4><p>An img element represents an image.</p>
+		<h4 id="the-link-element"><code>link</code></h4><p>The link element allows authors to link their document to other resources.</p>
+		<h2 id="index"></h2>
+		<table id="attributes-1">
+			<caption>List of attributes (excluding event handler content attributes)</caption>
+			<thead><tr><th>Attribute<th>Element(s)<th>Description<th>Value</tr></thead>
+			<tbody>
+				<tr><th><code>href</code><td><code><a>a</a></code>; <code><a>area</a></code><td>Address of the hyperlink<td>Valid URL potentially surrounded by spaces</tr>
+				<tr><th><code>ping</code><td><code><a>a</a></code><td>URLs to ping<td>Set of space-separated tokens consisting of valid non-empty URLs</tr>
+				<tr><th><code>srcset</code><td><code><a>img</a></code><td>Images to use<td>Comma-separated list of image candidate strings</tr>
+				<tr><th><code>sizes</code><td><code><a>link</a></code><td>Sizes of the icons<td>Unordered set of unique space-separated tokens, ASCII case-insensitive, consisting of sizes</tr>
+				<tr><th><code>style</code><td><a>HTML elements</a><td>Presentational hints<td>CSS declarations</tr>
+				<tr><th><code>title</code><td><a>HTML elements</a><td>Advisory information<td>Text</tr>
+			</tbody>
+		</table>
+		<table id="ix-event-handlers">
+			<caption>List of event handler content attributes</caption>
+			<tbody>
+				<tr><th><code>onafterprint</code><td><code><a>body</a></code><td>afterprint event handler<td>Event handler content attribute</tr>
+			</tbody>
+		</table>
+	</body>
+</html>
+`
+
+	got, err := GenerateHTMLSpec(io.NopCloser(bytes.NewBufferString(htmlDoc)))
+	if err != nil {
+		t.Fatalf("GenerateHTMLSpec() error = %v", err)
+	}
+
+	tests := []struct {
+		tag  string
+		name string
+		want SecurityClass
+	}{
+		{tag: "a", name: "href", want: SecurityURL},
+		{tag: "a", name: "ping", want: SecurityURLList},
+		{tag: "a", name: "target", want: SecurityText},
+		{tag: "img", name: "srcset", want: SecurityURLList},
+		{tag: "img", name: "style", want: SecurityStyle},
+		{tag: "img", name: "title", want: SecurityText},
+		{tag: "img", name: "data-src", want: SecurityText},
+		// Only the index tells that the sizes of link are icon sizes rather than the source sizes of img.
+		{tag: "link", name: "sizes", want: SecurityText},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag+" "+tt.name, func(t *testing.T) {
+			attr, ok := got.LookupAttribute(tt.tag, tt.name)
+			if !ok {
+				t.Fatalf("LookupAttribute(%q, %q) found nothing", tt.tag, tt.name)
+			}
+			if attr.Info().Security != tt.want {
+				t.Errorf("LookupAttribute(%q, %q) security = %v, want %v", tt.tag, tt.name, attr.Info().Security, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateHTMLSpec_TokenVocabularies(t *testing.T) {
+	htmlDoc := `
+<html>
+	<body>
+		<h2 id="semantics"></h2>
+		<h4 id="the-link-element"><code>link</code></h4><p>The link element allows authors to link their document to other resources.</p>
+		<h4 id="the-a-element"><code>a</code></h4><p>The a element represents a hyperlink.</p>
+		<h4 id="the-iframe-element"><code>iframe</code></h4>
+		<p>The iframe element represents its content navigable.</p>
+		<p>The allowed values are <code data-x="attr-iframe-sandbox-allow-forms">allow-forms</code> and <code data-x="attr-iframe-sandbox-allow-scripts">allow-scripts</code>.</p>
+		<h4 id="the-input-element"><code>input</code></h4><p>The input element represents a typed data field.</p>
+		<p>Tokens: <code data-x="attr-fe-autocomplete-section">section-*</code>, <code data-x="attr-fe-autocomplete-email">email</code>, <code data-x="attr-fe-autocomplete">autocomplete</code>.</p>
+		<h2 id="links"></h2>
+		<table>
+			<thead>
+				<tr><th rowspan="2">Link type<th colspan="3">Effect on...<th rowspan="2">Body-ok<th rowspan="2">Brief description</tr>
+				<tr><th><code>link</code><th><code>a</code> and <code>area</code><th><code>form</code></tr>
+			</thead>
+			<tbody>
+				<tr><td><code data-x="rel-icon">icon</code><td>External Resource<td><em>not allowed</em><td><em>not allowed</em><td>No<td>Icon</tr>
+				<tr><td><code data-x="rel-noopener">noopener</code><td><em>not allowed</em><td>Annotation<td>Annotation<td>No<td>No opener</tr>
+				<tr><td><code data-x="rel-next">next</code><td>Hyperlink<td>Hyperlink<td>Hyperlink<td>No<td>Next</tr>
+			</tbody>
+		</table>
+	</body>
+</html>
+`
+
+	got, err := GenerateHTMLSpec(io.NopCloser(bytes.NewBufferString(htmlDoc)))
+	if err != nil {
+		t.Fatalf("GenerateHTMLSpec() error = %v", err)
+	}
+
+	tests := []struct {
+		tag, name   string
+		wantAllowed []string
+	}{
+		{tag: "link", name: "rel", wantAllowed: []string{"icon", "next"}},
+		{tag: "a", name: "rel", wantAllowed: []string{"next", "noopener"}},
+		{tag: "iframe", name: "sandbox", wantAllowed: []string{"allow-forms", "allow-scripts"}},
+		{tag: "input", name: "autocomplete", wantAllowed: []string{"email"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag+" "+tt.name, func(t *testing.T) {
+			attr, ok := got.LookupAttribute(tt.tag, tt.name)
+			if !ok {
+				t.Fatalf("LookupAttribute(%q, %q) found nothing", tt.tag, tt.name)
+			}
+			sst, ok := attr.(*AttributeTypeSST)
+			if !ok {
+				t.Fatalf("LookupAttribute(%q, %q) = %T, want *AttributeTypeSST", tt.tag, tt.name, attr)
+			}
+			if gotAllowed := slices.Sorted(maps.Keys(sst.Allowed)); !slices.Equal(gotAllowed, tt.wantAllowed) {
+				t.Errorf("LookupAttribute(%q, %q).Allowed = %v, want %v", tt.tag, tt.name, gotAllowed, tt.wantAllowed)
+			}
 		})
 	}
+
+	if err = got.CheckAttributeValue("a", "rel", "next noopener"); err != nil {
+		t.Errorf(`CheckAttributeValue("a", "rel", "next noopener") error = %v`, err)
+	}
+	// noopner is misspelled and icon is not allowed on a.
+	for _, value := range []string{"noopner", "icon"} {
+		if err = got.CheckAttributeValue("a", "rel", value); err == nil {
+			t.Errorf("CheckAttributeValue(\"a\", \"rel\", %q) error = nil, want an error", value)
+		}
+	}
+
+	autocomplete, _ := got.LookupAttribute("input", "autocomplete")
+	if prefixes := autocomplete.(*AttributeTypeSST).Prefixes; !slices.Equal(prefixes, []string{"section-"}) {
+		t.Errorf("input autocomplete Prefixes = %v, want [section-]", prefixes)
+	}
+}
+
+func TestGenerateHTMLSpec_Obsolete(t *testing.T) {
+	htmlDoc := `
+<html>
+	<body>
+		<h2 id="semantics"></h2>
+		<h4 id="the-table-element"><code>table</code></h4><p>The table element represents data with more than one dimension.</p>
+		<h2 id="obsolete"></h2>
+		<h3 id="non-conforming-features"><span class="secno">16.2</span> Non-conforming features</h3>
+		<dl>
+			<dt><dfn><code>applet</code></dfn></dt>
+			<dd>Use <code>embed</code> or <code>object</code> instead.</dd>
+			<dt><dfn><code>center</code></dfn></dt>
+			<dt><dfn><code>font</code></dfn></dt>
+			<dd>Use CSS instead.</dd>
+		</dl>
+		<dl>
+			<dt><code>align</code> on <code>table</code> elements</dt>
+			<dd>Use CSS instead.</dd>
+		</dl>
+	</body>
+</html>
+`
+
+	got, err := GenerateHTMLSpec(io.NopCloser(bytes.NewBufferString(htmlDoc)))
+	if err != nil {
+		t.Fatalf("GenerateHTMLSpec() error = %v", err)
+	}
+
+	tests := []struct {
+		tag             string
+		wantObsolete    bool
+		wantReplacement string
+	}{
+		{tag: "applet", wantObsolete: true, wantReplacement: "Use embed or object instead."},
+		{tag: "center", wantObsolete: true, wantReplacement: "Use CSS instead."},
+		{tag: "font", wantObsolete: true, wantReplacement: "Use CSS instead."},
+		{tag: "table"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			e, ok := got.Element(tt.tag)
+			if !ok {
+				t.Fatalf("GenerateHTMLSpec() did not find %s", tt.tag)
+			}
+			if e.Obsolete != tt.wantObsolete || e.Replacement != tt.wantReplacement {
+				t.Errorf("GenerateHTMLSpec() %s = (%v, %q), want (%v, %q)", tt.tag, e.Obsolete, e.Replacement,
+					tt.wantObsolete, tt.wantReplacement)
+			}
+		})
+	}
+
+	align, ok := got.LookupAttribute("table", "align")
+	if !ok {
+		t.Fatal("GenerateHTMLSpec() did not find align on table")
+	}
+	if !align.Info().Obsolete || align.Info().Replacement != "Use CSS instead." {
+		t.Errorf("GenerateHTMLSpec() table align = %+v", align.Info())
+	}
+
+	got.RemoveObsolete()
+	if _, ok := got.Element("center"); ok {
+		t.Error("RemoveObsolete() kept center")
+	}
+	if _, ok := got.LookupAttribute("table", "align"); ok {
+		t.Error("RemoveObsolete() kept align on table")
+	}
+	if _, ok := got.Element("table"); !ok {
+		t.Error("RemoveObsolete() removed table")
+	}
+}
+
+func TestGenerateHTMLSpecWithWarnings_ElementIndex(t *testing.T) {
+	htmlDoc := `
+<html>
+	<body>
+		<h2 id="semantics"></h2>
+		<h4 id="the-p-element"><code>p</code></h4>
+		<dl class="element"><dt>Categories:</dt><dd>Flow content.</dd><dd>Palpable content.</dd></dl>
+		<p>The p element represents a paragraph.</p>
+		<h4 id="the-hr-element"><code>hr</code></h4><p>The hr element represents a paragraph-level thematic break.</p>
+		<h4 id="the-foo-element">The foo element</h4>
+		<h4 id="the-a-element"><code>a</code></h4>
+		<dl class="element"><dt>Categories:</dt><dd>Flow content.</dd><dd>Phrasing content.</dd><dd>Interactive content.</dd></dl>
+		<p>The a element represents a hyperlink.</p>
+		<h2 id="index"></h2>
+		<table>
+			<caption>List of elements</caption>
+			<thead><tr><th>Element</th><th>Description</th><th>Categories</th><th>Parents</th><th>Children</th><th>Attributes</th><th>Interface</th></tr></thead>
+			<tbody>
+				<tr><th><code><a>p</a></code></th><td>Paragraph</td><td>flow; palpable</td><td>flow</td><td>phrasing</td><td>globals</td><td>HTMLParagraphElement</td></tr>
+				<tr><th><code><a>a</a></code></th><td>Hyperlink</td><td>flow; phrasing*; interactive*; palpable</td><td>phrasing</td><td>transparent*</td><td>globals; href; target; download; ping; rel; hreflang; type; name</td><td>HTMLAnchorElement</td></tr>
+				<tr><th><code><a>br</a></code></th><td>Line break</td><td>flow; phrasing</td><td>phrasing</td><td>empty</td><td>globals</td><td>HTMLBRElement</td></tr>
+				<tr><th><code>math</code></th><td>MathML root</td><td>flow; phrasing</td><td>phrasing</td><td>per [MATHML]</td><td>per [MATHML]</td><td>Element</td></tr>
+			</tbody>
+		</table>
+	</body>
+</html>
+`
+
+	_, warnings, err := GenerateHTMLSpecWithWarnings(io.NopCloser(bytes.NewBufferString(htmlDoc)))
+	if err != nil {
+		t.Fatalf("GenerateHTMLSpecWithWarnings() error = %v", err)
+	}
+
+	want := []Warning{
+		{Message: `h4 id "the-foo-element" has no code child`, Position: Position{Offset: 346, Line: 9, Column: 3}},
+		{Message: `element "hr" has a section but is not in the index of elements`, Position: Position{Offset: 233, Line: 8, Column: 3}},
+		{Message: `element "a" is not palpable content but the index of elements lists it as such`, Position: Position{Offset: 394, Line: 10, Column: 3}},
+		{Message: `element "a" has attributes referrerpolicy that are not in the index of elements`, Position: Position{Offset: 394, Line: 10, Column: 3}},
+		{Message: `element "a" is missing attributes name that the index of elements lists`, Position: Position{Offset: 394, Line: 10, Column: 3}},
+		{Message: `element "br" is in the index of elements but its section was not found`, Position: Position{Offset: 1256, Line: 20, Column: 5}},
+	}
+	if !reflect.DeepEqual(warnings, want) {
+		t.Errorf("GenerateHTMLSpecWithWarnings() warnings = %v, want %v", warnings, want)
+	}
+}
+
+func TestGenerateHTMLSpec_URLs(t *testing.T) {
+	htmlDoc := `
+<html>
+	<body>
+		<h2 id="semantics"></h2>
+		<h4 id="the-a-element"><code>a</code></h4><p>The <code><a href="#the-a-element">a</a></code> element represents a hyperlink.</p>
+		<h2 id="index"></h2>
+		<table>
+			<caption>List of attributes (excluding event handler content attributes)</caption>
+			<tbody>
+				<tr><th><code>href</code><td><code><a href="#attr-hyperlink-href">a</a></code>; <code><a href="#attr-hyperlink-href">area</a></code><td>Address of the hyperlink<td>Valid URL potentially surrounded by spaces</tr>
+				<tr><th><code>title</code><td><a href="#attr-title">HTML elements</a><td>Advisory information<td>Text</tr>
+			</tbody>
+		</table>
+	</body>
+</html>
+`
+
+	got, err := GenerateHTMLSpec(io.NopCloser(bytes.NewBufferString(htmlDoc)))
+	if err != nil {
+		t.Fatalf("GenerateHTMLSpec() error = %v", err)
+	}
+	if err = got.ResolveURLs("https://html.spec.whatwg.org/"); err != nil {
+		t.Fatalf("ResolveURLs() error = %v", err)
+	}
+
+	a, _ := got.Element("a")
+	if want := "https://html.spec.whatwg.org/#the-a-element"; a.URL != want {
+		t.Errorf("a.URL = %v, want %v", a.URL, want)
+	}
+	if want := "The [`a`](https://html.spec.whatwg.org/#the-a-element) element represents a hyperlink."; a.Description != want {
+		t.Errorf("a.Description = %v, want %v", a.Description, want)
+	}
+
+	tests := []struct {
+		tag  string
+		name string
+		want string
+	}{
+		{tag: "a", name: "href", want: "https://html.spec.whatwg.org/#attr-hyperlink-href"},
+		{tag: "a", name: "title", want: "https://html.spec.whatwg.org/#attr-title"},
+		{tag: "a", name: "target", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag+" "+tt.name, func(t *testing.T) {
+			attr, ok := got.LookupAttribute(tt.tag, tt.name)
+			if !ok {
+				t.Fatalf("LookupAttribute(%q, %q) found nothing", tt.tag, tt.name)
+			}
+			if attr.Info().URL != tt.want {
+				t.Errorf("LookupAttribute(%q, %q) URL = %v, want %v", tt.tag, tt.name, attr.Info().URL, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateHTMLSpec_Stability(t *testing.T) {
+	htmlDoc := `
+<html>
+	<body>
+		<h2 id="semantics"></h2>
+		<h4 id="the-template-element"><code>template</code></h4>
+		<div class="mdn-anno wrapped"><button class="mdn-anno-btn"><b class="all-engines-flag" title="Support in all current engines.">✔</b><span>MDN</span></button></div>
+		<dl class="element"></dl>
+		<p>The template element is used to declare fragments of HTML.</p>
+		<div class="mdn-anno wrapped"><button class="mdn-anno-btn"><b class="less-than-two-engines-flag" title="Support in one engine only.">⚠</b><span>MDN</span></button></div>
+		<p>The <dfn id="attr-template-shadowrootcustomelementregistry"><code>shadowrootcustomelementregistry</code></dfn> content attribute is a boolean attribute.</p>
+		<p>The <dfn id="attr-template-shadowrootmode"><code>shadowrootmode</code></dfn> content attribute is an enumerated attribute.</p>
+		<h4 id="the-selectedcontent-element"><code>selectedcontent</code></h4>
+		<div class="mdn-anno wrapped"><button class="mdn-anno-btn"><b class="less-than-two-engines-flag" title="Support in one engine only.">⚠</b><span>MDN</span></button></div>
+		<dl class="element"></dl>
+		<p>The selectedcontent element mirrors the contents of the selected option.</p>
+		<h2 id="index"></h2>
+		<table>
+			<caption>List of attributes (excluding event handler content attributes)</caption>
+			<tbody>
+				<tr><th><code>shadowrootcustomelementregistry</code><td><code><a href="#attr-template-shadowrootcustomelementregistry">template</a></code><td>Enables declarative shadow roots to indicate they will use a custom element registry<td>Boolean attribute</tr>
+				<tr><th><code>shadowrootmode</code><td><code><a href="#attr-template-shadowrootmode">template</a></code><td>Enables streaming declarative shadow roots<td>"open"; "closed"</tr>
+			</tbody>
+		</table>
+	</body>
+</html>
+`
+
+	got, err := GenerateHTMLSpec(io.NopCloser(bytes.NewBufferString(htmlDoc)))
+	if err != nil {
+		t.Fatalf("GenerateHTMLSpec() error = %v", err)
+	}
+
+	template, _ := got.Element("template")
+	selectedcontent, _ := got.Element("selectedcontent")
+	registry, _ := got.LookupAttribute("template", "shadowrootcustomelementregistry")
+	mode, _ := got.LookupAttribute("template", "shadowrootmode")
+
+	tests := []struct {
+		name string
+		got  Stability
+		want Stability
+	}{
+		{name: "template", got: template.Stability, want: StabilityStable},
+		{name: "selectedcontent", got: selectedcontent.Stability, want: StabilityLimited},
+		{name: "shadowrootcustomelementregistry", got: registry.Info().Stability, want: StabilityLimited},
+		{name: "shadowrootmode", got: mode.Info().Stability, want: StabilityStable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("GenerateHTMLSpec() %s stability = %q, want %q", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+
+	got.RemoveUnstable()
+	if _, ok := got.Element("selectedcontent"); ok {
+		t.Error("RemoveUnstable() kept selectedcontent")
+	}
+	if _, ok := got.LookupAttribute("template", "shadowrootcustomelementregistry"); ok {
+		t.Error("RemoveUnstable() kept shadowrootcustomelementregistry")
+	}
+}
+
+func TestGenerateHTMLSpec_NoElements(t *testing.T) {
+	htmlDoc := "<html>\n<body>\n<h2 id=\"semantics\"></h2>\n<h4 id=\"the-foo-element\">foo</h4>\n</body>\n</html>"
+
+	_, err := GenerateHTMLSpec(io.NopCloser(bytes.NewBufferString(htmlDoc)))
+
+	var posErr *PositionError
+	if !errors.As(err, &posErr) {
+		t.Fatalf("GenerateHTMLSpec() error = %v, want a *PositionError", err)
+	}
+	if posErr.Position.Line != 3 {
+		t.Errorf("GenerateHTMLSpec() error = %v, want it on line 3", err)
+	}
 }
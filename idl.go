@@ -0,0 +1,97 @@
+package spec
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Reflection describes an IDL attribute of an element's DOM interface that reflects a content attribute.
+type Reflection struct {
+	// IDLName is the name of the attribute on the DOM interface, e.g. htmlFor for the for content attribute.
+	IDLName string `json:"idl_name"`
+	// Type is the IDL type of the attribute, e.g. DOMString or unsigned long.
+	Type string `json:"type"`
+}
+
+var (
+	idlInterface = regexp.MustCompile(`\binterface\s+(\w+)`)
+	idlUses      = regexp.MustCompile(`\bUses\s+(\w+)`)
+	idlAttribute = regexp.MustCompile(`\[([^\]]*)\]\s*(?:readonly\s+)?attribute\s+([^;]+?)\s+(\w+)\s*;`)
+)
+
+// parseInterface reads the interface name and reflected attributes from the text of a "DOM interface" entry.
+// Elements without their own interface have an entry such as "Uses HTMLElement." instead of IDL.
+func parseInterface(text string) (string, map[string]Reflection) {
+	match := idlInterface.FindStringSubmatch(text)
+	if match == nil {
+		if match = idlUses.FindStringSubmatch(text); match == nil {
+			return "", nil
+		}
+
+		return match[1], nil
+	}
+
+	reflections := make(map[string]Reflection)
+	for _, attr := range idlAttribute.FindAllStringSubmatch(text, -1) {
+		content, ok := reflectedName(attr[1], attr[3])
+		if !ok {
+			continue
+		}
+
+		reflections[content] = Reflection{
+			IDLName: attr[3],
+			Type:    strings.Join(strings.Fields(attr[2]), " "),
+		}
+	}
+
+	if len(reflections) == 0 {
+		reflections = nil
+	}
+
+	return match[1], reflections
+}
+
+// reflectedName returns the content attribute reflected by an IDL attribute with the given extended attributes.
+// [Reflect] reflects the lowercased IDL name while [Reflect=name] names the content attribute explicitly.
+// Variants such as [ReflectURL] reflect the lowercased IDL name too.
+func reflectedName(extended, idlName string) (string, bool) {
+	for _, ext := range splitExtendedAttributes(extended) {
+		name, value, _ := strings.Cut(ext, "=")
+		name = strings.TrimSpace(name)
+
+		if !strings.HasPrefix(name, "Reflect") || name == "ReflectSetter" {
+			continue
+		}
+
+		if name == "Reflect" && value != "" {
+			return strings.Trim(strings.TrimSpace(value), `"`), true
+		}
+
+		return strings.ToLower(idlName), true
+	}
+
+	return "", false
+}
+
+// splitExtendedAttributes splits an extended attribute list on the commas that are not inside parentheses.
+func splitExtendedAttributes(s string) []string {
+	var out []string
+
+	depth := 0
+	last := 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				out = append(out, strings.TrimSpace(s[last:i]))
+				last = i + 1
+			}
+		}
+	}
+
+	return append(out, strings.TrimSpace(s[last:]))
+}
@@ -0,0 +1,27 @@
+// Package htmltext holds the text handling shared by the packages that write HTML.
+package htmltext
+
+import "strings"
+
+// CollapseWhitespace replaces each run of ASCII whitespace in s with a single space.
+func CollapseWhitespace(s string) string {
+	var b strings.Builder
+
+	space := false
+	for _, r := range s {
+		if strings.ContainsRune(" \t\n\f\r", r) {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+
+	return b.String()
+}
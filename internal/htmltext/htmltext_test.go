@@ -0,0 +1,20 @@
+package htmltext
+
+import "testing"
+
+func TestCollapseWhitespace(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: ""},
+		{in: "a", want: "a"},
+		{in: "  a \n\t b  ", want: " a b "},
+		{in: "a  b", want: "a  b"},
+	}
+	for _, tt := range tests {
+		if got := CollapseWhitespace(tt.in); got != tt.want {
+			t.Errorf("CollapseWhitespace(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package spec
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// kindTerms maps the terms of the syntax chapter's list of element kinds to the kind they define.
+var kindTerms = map[string]ElementKind{
+	"void elements":               ElementKindVoid,
+	"the template element":        ElementKindTemplate,
+	"raw text elements":           ElementKindRawText,
+	"escapable raw text elements": ElementKindEscapableRawText,
+	"foreign elements":            ElementKindForeign,
+	"normal elements":             ElementKindNormal,
+}
+
+// fallbackKinds is used when the syntax chapter could not be found, elements that are not listed are normal elements.
+var fallbackKinds = map[string]ElementKind{
+	"area":     ElementKindVoid,
+	"base":     ElementKindVoid,
+	"br":       ElementKindVoid,
+	"col":      ElementKindVoid,
+	"embed":    ElementKindVoid,
+	"hr":       ElementKindVoid,
+	"img":      ElementKindVoid,
+	"input":    ElementKindVoid,
+	"link":     ElementKindVoid,
+	"meta":     ElementKindVoid,
+	"source":   ElementKindVoid,
+	"track":    ElementKindVoid,
+	"wbr":      ElementKindVoid,
+	"template": ElementKindTemplate,
+	"script":   ElementKindRawText,
+	"style":    ElementKindRawText,
+	"textarea": ElementKindEscapableRawText,
+	"title":    ElementKindEscapableRawText,
+}
+
+// parseElementKinds reads the tags listed for each kind in the dl of the syntax chapter's "Elements" section.
+// Kinds that are described in prose rather than by a list of tags, such as foreign elements, are left out.
+func parseElementKinds(dl *html.Node) map[string]ElementKind {
+	out := make(map[string]ElementKind)
+
+	for term, dds := range definitions(dl) {
+		kind, ok := kindTerms[strings.ToLower(term)]
+		if !ok {
+			continue
+		}
+
+		for _, dd := range dds {
+			for _, tag := range codeSpans(gatherCodeText(dd)) {
+				out[tag] = kind
+			}
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,155 @@
+package spec
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// LinkEffect is what a link type does when used in the rel attribute of an element.
+type LinkEffect string
+
+const (
+	// LinkEffectHyperlink link types create a hyperlink, e.g. next.
+	LinkEffectHyperlink LinkEffect = "hyperlink"
+	// LinkEffectExternalResource link types create a link to a resource that is fetched, e.g. stylesheet.
+	LinkEffectExternalResource LinkEffect = "external-resource"
+	// LinkEffectAnnotation link types change how other links behave, e.g. noopener.
+	LinkEffectAnnotation LinkEffect = "annotation"
+	// LinkEffectNotAllowed link types cannot be used on the element.
+	LinkEffectNotAllowed LinkEffect = "not-allowed"
+)
+
+// LinkType is a keyword of the rel attribute as listed in the spec's table of link types.
+type LinkType struct {
+	Keyword string `json:"keyword"`
+	// Link is the effect of the keyword on link elements.
+	Link LinkEffect `json:"link"`
+	// AnchorArea is the effect of the keyword on a and area elements.
+	AnchorArea LinkEffect `json:"a_area"`
+	// Form is the effect of the keyword on form elements.
+	Form LinkEffect `json:"form"`
+	// BodyOK is set for keywords that make a link element allowed in the body.
+	BodyOK      bool   `json:"body_ok,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Effect returns the effect of the link type on the element with the given tag.
+// Elements without a rel attribute are reported as LinkEffectNotAllowed.
+func (lt *LinkType) Effect(tag string) LinkEffect {
+	switch tag {
+	case "link":
+		return lt.Link
+	case "a", "area":
+		return lt.AnchorArea
+	case "form":
+		return lt.Form
+	}
+
+	return LinkEffectNotAllowed
+}
+
+// AllowedOn reports if the link type can be used in the rel attribute of the element with the given tag.
+func (lt *LinkType) AllowedOn(tag string) bool {
+	effect := lt.Effect(tag)
+	return effect != "" && effect != LinkEffectNotAllowed
+}
+
+// Identifier returns the keyword as an exported Go identifier, e.g. DnsPrefetch for dns-prefetch,
+// for generating constants such as Preload and Stylesheet.
+func (lt *LinkType) Identifier() string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(lt.Keyword, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+
+	return b.String()
+}
+
+// LinkType returns the link type with the given keyword, which is matched ASCII case-insensitively.
+func (sp *Spec) LinkType(keyword string) (*LinkType, bool) {
+	for _, lt := range sp.LinkTypes {
+		if strings.EqualFold(lt.Keyword, keyword) {
+			return lt, true
+		}
+	}
+
+	return nil, false
+}
+
+// LinkTypesFor returns the link types that can be used in the rel attribute of the element with the given tag,
+// in spec order.
+func (sp *Spec) LinkTypesFor(tag string) []*LinkType {
+	var out []*LinkType
+	for _, lt := range sp.LinkTypes {
+		if lt.AllowedOn(tag) {
+			out = append(out, lt)
+		}
+	}
+
+	return out
+}
+
+// parseLinkTypes reads the table of link types, whose rows hold a keyword, its effect on link, on a and area, and
+// on form elements, whether it is body-ok and a brief description.
+// Rows listing more than one keyword, such as alternate spellings, give a LinkType for each keyword.
+func parseLinkTypes(table *html.Node) []*LinkType {
+	var out []*LinkType
+
+	for tr := range table.Descendants() {
+		if tr.Type != html.ElementNode || tr.Data != "tr" {
+			continue
+		}
+
+		var cells []*html.Node
+		for cell := range tr.ChildNodes() {
+			if cell.Type == html.ElementNode && cell.Data == "td" {
+				cells = append(cells, cell)
+			}
+		}
+		if len(cells) < 4 {
+			continue
+		}
+
+		var bodyOK bool
+		var description string
+		if len(cells) > 4 {
+			bodyOK = strings.EqualFold(strings.TrimSpace(rawText(cells[4])), "yes")
+		}
+		if len(cells) > 5 {
+			description = strings.Join(strings.Fields(rawText(cells[5])), " ")
+		}
+
+		for _, keyword := range codeSpans(gatherCodeText(cells[0])) {
+			out = append(out, &LinkType{
+				Keyword:     strings.ToLower(keyword),
+				Link:        linkEffect(rawText(cells[1])),
+				AnchorArea:  linkEffect(rawText(cells[2])),
+				Form:        linkEffect(rawText(cells[3])),
+				BodyOK:      bodyOK,
+				Description: description,
+			})
+		}
+	}
+
+	return out
+}
+
+func linkEffect(text string) LinkEffect {
+	text = strings.ToLower(text)
+
+	switch {
+	case strings.Contains(text, "not allowed"):
+		return LinkEffectNotAllowed
+	case strings.Contains(text, "external resource"):
+		return LinkEffectExternalResource
+	case strings.Contains(text, "hyperlink"):
+		return LinkEffectHyperlink
+	case strings.Contains(text, "annotation"):
+		return LinkEffectAnnotation
+	}
+
+	return ""
+}
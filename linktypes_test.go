@@ -0,0 +1,82 @@
+package spec
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func Test_parseLinkTypes(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<table>
+		<thead>
+			<tr><th rowspan="2">Link type<th colspan="3">Effect on...<th rowspan="2">Body-ok<th rowspan="2">Brief description</tr>
+			<tr><th><code>link</code><th><code>a</code> and <code>area</code><th><code>form</code></tr>
+		</thead>
+		<tbody>
+			<tr><td><code>dns-prefetch</code><td>External Resource<td><em>not allowed</em><td><em>not allowed</em><td>Yes<td>Specifies that the user agent should preemptively perform DNS resolution.</tr>
+			<tr><td><code>noopener</code><td><em>not allowed</em><td>Annotation<td>Annotation<td>No<td>Creates a top-level traversable with a non-auxiliary browsing context.</tr>
+			<tr><td><code>next</code><td>Hyperlink<td>Hyperlink<td>Hyperlink<td>No<td>Indicates that the current document is a part of a series.</tr>
+		</tbody>
+	</table>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, _ := findTag(doc, "table")
+
+	want := []*LinkType{
+		{
+			Keyword:     "dns-prefetch",
+			Link:        LinkEffectExternalResource,
+			AnchorArea:  LinkEffectNotAllowed,
+			Form:        LinkEffectNotAllowed,
+			BodyOK:      true,
+			Description: "Specifies that the user agent should preemptively perform DNS resolution.",
+		},
+		{
+			Keyword:     "noopener",
+			Link:        LinkEffectNotAllowed,
+			AnchorArea:  LinkEffectAnnotation,
+			Form:        LinkEffectAnnotation,
+			Description: "Creates a top-level traversable with a non-auxiliary browsing context.",
+		},
+		{
+			Keyword:     "next",
+			Link:        LinkEffectHyperlink,
+			AnchorArea:  LinkEffectHyperlink,
+			Form:        LinkEffectHyperlink,
+			Description: "Indicates that the current document is a part of a series.",
+		},
+	}
+
+	got := parseLinkTypes(table)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseLinkTypes() = %+v, want %+v", got, want)
+	}
+
+	sp := &Spec{LinkTypes: got}
+
+	if lt, ok := sp.LinkType("NoOpener"); !ok || lt.Keyword != "noopener" {
+		t.Errorf("LinkType(NoOpener) = %v, %v", lt, ok)
+	}
+	if _, ok := sp.LinkType("stylesheet"); ok {
+		t.Error("LinkType(stylesheet) found a link type that is not in the table")
+	}
+
+	var keywords []string
+	for _, lt := range sp.LinkTypesFor("area") {
+		keywords = append(keywords, lt.Keyword)
+	}
+	if !slices.Equal(keywords, []string{"noopener", "next"}) {
+		t.Errorf("LinkTypesFor(area) = %v, want [noopener next]", keywords)
+	}
+	if sp.LinkTypesFor("div") != nil {
+		t.Error("LinkTypesFor(div) returned link types for an element without rel")
+	}
+
+	if id := got[0].Identifier(); id != "DnsPrefetch" {
+		t.Errorf("Identifier() = %v, want DnsPrefetch", id)
+	}
+}
@@ -0,0 +1,298 @@
+// Package lint checks HTML documents against a Spec with a set of rules that can be configured individually.
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-htemel/spec"
+	"golang.org/x/net/html"
+)
+
+// Severity is how serious the problems a rule finds are.
+type Severity string
+
+const (
+	// SeverityOff disables a rule.
+	SeverityOff     Severity = "off"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Diagnostic is a problem found in a document.
+// Line and Column are 1-based, with columns counted in characters.
+type Diagnostic struct {
+	Path     string   `json:"path"`
+	Line     int      `json:"line"`
+	Column   int      `json:"column"`
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s [%s]", d.Path, d.Line, d.Column, d.Severity, d.Message, d.Rule)
+}
+
+// Config sets the severity of the rules of a Linter.
+type Config struct {
+	// Rules maps rule names to their severity, rules that are not listed keep their default severity.
+	Rules map[string]Severity `json:"rules"`
+}
+
+// LoadConfig reads a Config from its JSON form, e.g. {"rules": {"img-alt": "warning", "redundant-role": "off"}}.
+func LoadConfig(r io.Reader) (Config, error) {
+	var cfg Config
+
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return cfg, err
+	}
+
+	for name, severity := range cfg.Rules {
+		if _, ok := findRule(name); !ok {
+			return cfg, fmt.Errorf("unknown rule %q", name)
+		}
+		switch severity {
+		case SeverityOff, SeverityWarning, SeverityError:
+		default:
+			return cfg, fmt.Errorf("rule %q: unknown severity %q", name, severity)
+		}
+	}
+
+	return cfg, nil
+}
+
+// Linter checks documents against a spec.
+type Linter struct {
+	spec       *spec.Spec
+	severities map[string]Severity
+}
+
+// New returns a Linter for documents conforming to sp, with the rules configured by cfg.
+func New(sp *spec.Spec, cfg Config) *Linter {
+	severities := make(map[string]Severity, len(rules))
+	for _, r := range rules {
+		severities[r.Name] = r.Severity
+		if severity, ok := cfg.Rules[r.Name]; ok {
+			severities[r.Name] = severity
+		}
+	}
+
+	return &Linter{
+		spec:       sp,
+		severities: severities,
+	}
+}
+
+// Lint checks the document src, reporting diagnostics in the order they appear in the document under the given path.
+func (l *Linter) Lint(path string, src []byte) []Diagnostic {
+	r := &run{
+		linter: l,
+		path:   path,
+		src:    src,
+		ids:    make(map[string]int),
+	}
+	for i, b := range src {
+		if b == '\n' {
+			r.lines = append(r.lines, i)
+		}
+	}
+
+	z := html.NewTokenizer(bytes.NewReader(src))
+	offset := 0
+	// foreign holds the names of the open SVG and MathML elements, whose tags are not checked against the spec.
+	var foreign []string
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+
+		// Token unescapes attribute values in place, so the raw bytes are copied before it is called.
+		raw := bytes.Clone(z.Raw())
+		start := offset
+		offset += len(raw)
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			if len(foreign) == 0 && !dynamic(tok.Data) {
+				r.check(newTag(tok, raw, start))
+			}
+			if tt == html.StartTagToken && (tok.Data == "svg" || tok.Data == "math" || len(foreign) > 0) {
+				foreign = append(foreign, tok.Data)
+			}
+		case html.EndTagToken:
+			// Foreign children such as path are often left unclosed, so an end tag closes everything opened after
+			// the element it names, and end tags that match no open element are ignored.
+			name, _ := z.TagName()
+			for i := len(foreign) - 1; i >= 0; i-- {
+				if foreign[i] == string(name) {
+					foreign = foreign[:i]
+					break
+				}
+			}
+		}
+	}
+
+	return r.diagnostics
+}
+
+// run holds the state of linting a single document.
+type run struct {
+	linter      *Linter
+	path        string
+	src         []byte
+	lines       []int
+	ids         map[string]int
+	diagnostics []Diagnostic
+	rule        *Rule
+}
+
+func (r *run) check(t *tag) {
+	for _, rule := range rules {
+		severity := r.linter.severities[rule.Name]
+		if severity == SeverityOff {
+			continue
+		}
+
+		r.rule = rule
+		rule.check(r, t)
+	}
+}
+
+// report adds a diagnostic for the current rule at the given byte offset of the document.
+func (r *run) report(offset int, format string, args ...any) {
+	line, column := r.position(offset)
+	r.diagnostics = append(r.diagnostics, Diagnostic{
+		Path:     r.path,
+		Line:     line,
+		Column:   column,
+		Rule:     r.rule.Name,
+		Severity: r.linter.severities[r.rule.Name],
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// position returns the line and column of the byte offset.
+func (r *run) position(offset int) (int, int) {
+	line := sort.SearchInts(r.lines, offset)
+	lineStart := 0
+	if line > 0 {
+		lineStart = r.lines[line-1] + 1
+	}
+
+	return line + 1, utf8.RuneCount(r.src[lineStart:offset]) + 1
+}
+
+// tag is a start tag of the document along with the byte offsets of it and of each of its attributes.
+type tag struct {
+	name    string
+	attrs   []html.Attribute
+	offset  int
+	offsets []int
+}
+
+// newTag returns the tag for the start tag token tok, whose raw text starts at offset start.
+// Attributes with names that hold a template action are left out.
+func newTag(tok html.Token, raw []byte, start int) *tag {
+	t := &tag{
+		name:   tok.Data,
+		offset: start,
+	}
+
+	offsets := attributeOffsets(raw, start)
+	for i, attr := range tok.Attr {
+		if dynamic(attr.Key) {
+			continue
+		}
+
+		t.attrs = append(t.attrs, attr)
+		if i < len(offsets) {
+			t.offsets = append(t.offsets, offsets[i])
+		}
+	}
+
+	return t
+}
+
+// attr returns the attribute with the given name and its offset.
+func (t *tag) attr(name string) (html.Attribute, int, bool) {
+	for i, attr := range t.attrs {
+		if attr.Key == name {
+			return attr, t.attrOffset(i), true
+		}
+	}
+
+	return html.Attribute{}, t.offset, false
+}
+
+func (t *tag) attrOffset(i int) int {
+	if i < len(t.offsets) {
+		return t.offsets[i]
+	}
+
+	return t.offset
+}
+
+// attributeOffsets returns the offsets of the attribute names of the raw start tag, which starts at offset start,
+// following the attribute name and value states of the HTML tokenizer.
+func attributeOffsets(raw []byte, start int) []int {
+	var out []int
+
+	i := 1
+	for i < len(raw) && !isTagSpace(raw[i]) && raw[i] != '/' && raw[i] != '>' {
+		i++
+	}
+
+	for i < len(raw) {
+		for i < len(raw) && (isTagSpace(raw[i]) || raw[i] == '/') {
+			i++
+		}
+		if i >= len(raw) || raw[i] == '>' {
+			break
+		}
+
+		out = append(out, start+i)
+		i++
+		for i < len(raw) && !isTagSpace(raw[i]) && raw[i] != '/' && raw[i] != '>' && raw[i] != '=' {
+			i++
+		}
+		for i < len(raw) && isTagSpace(raw[i]) {
+			i++
+		}
+		if i >= len(raw) || raw[i] != '=' {
+			continue
+		}
+
+		i++
+		for i < len(raw) && isTagSpace(raw[i]) {
+			i++
+		}
+		if i < len(raw) && (raw[i] == '"' || raw[i] == '\'') {
+			quote := raw[i]
+			i++
+			for i < len(raw) && raw[i] != quote {
+				i++
+			}
+			i++
+			continue
+		}
+		for i < len(raw) && !isTagSpace(raw[i]) && raw[i] != '>' {
+			i++
+		}
+	}
+
+	return out
+}
+
+func isTagSpace(b byte) bool {
+	return strings.IndexByte(" \t\n\f\r", b) != -1
+}
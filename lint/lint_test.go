@@ -0,0 +1,196 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-htemel/spec"
+)
+
+func testSpec() *spec.Spec {
+	return &spec.Spec{
+		Name: "HTML",
+		Attributes: []spec.Attribute{
+			&spec.AttributeTypeString{Name: "id"},
+			&spec.AttributeTypeString{Name: "class"},
+			&spec.AttributeTypeString{Name: "title"},
+			&spec.AttributeTypeString{Name: "role"},
+			&spec.AttributeTypeBool{Name: "hidden"},
+			&spec.AttributeTypePrefixedCustom{Name: "data"},
+			&spec.AttributeTypePrefixedCustom{Name: "aria"},
+		},
+		Elements: []*spec.Element{
+			{Tag: "div", Text: true},
+			{Tag: "p", Text: true},
+			{Tag: "table"},
+			{Tag: "tr"},
+			{Tag: "input", Void: true, Attributes: []spec.Attribute{
+				&spec.AttributeTypeEnum{Name: "type", Allowed: map[string]struct{}{"text": {}, "checkbox": {}}},
+				&spec.AttributeTypeString{Name: "value"},
+			}},
+			{Tag: "nav", Text: true},
+			{Tag: "img", Void: true, Attributes: []spec.Attribute{
+				&spec.AttributeTypeString{Name: "alt"},
+				&spec.AttributeTypeString{Name: "src"},
+			}},
+			{Tag: "a", Text: true, Attributes: []spec.Attribute{
+				&spec.AttributeTypeString{Name: "href"},
+				&spec.AttributeTypeString{Name: "target"},
+				&spec.AttributeTypeSST{Name: "rel"},
+			}},
+			{Tag: "td", Text: true, Attributes: []spec.Attribute{
+				&spec.AttributeTypeNumber{Name: "colspan", Min: pointer(1)},
+				&spec.AttributeTypeString{Name: "bgcolor", AttributeInfo: spec.AttributeInfo{
+					Obsolete:    true,
+					Replacement: "Use CSS instead.",
+				}},
+			}},
+			{Tag: "center", Text: true, Obsolete: true, Replacement: "Use CSS instead."},
+		},
+	}
+}
+
+func pointer[T any](v T) *T {
+	return &v
+}
+
+func TestLinter_Lint(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		src  string
+		want []string
+	}{
+		{
+			name: "img alt",
+			src:  "<img src=a.png>\n<img src=b.png alt=\"\">",
+			want: []string{"t.html:1:1: error: img element is missing an alt attribute [img-alt]"},
+		},
+		{
+			name: "duplicate id",
+			src:  "<div id=main></div>\n<nav  id=\"main\"></nav>",
+			want: []string{`t.html:2:7: error: id "main" is already used on line 1 [duplicate-id]`},
+		},
+		{
+			name: "target blank",
+			src:  `<a href=/ target=_blank>x</a><a href=/ target=_blank rel="noreferrer">y</a>`,
+			want: []string{"t.html:1:11: warning: a element with target=_blank is missing rel=noopener [target-blank-noopener]"},
+		},
+		{
+			name: "entities before attribute",
+			src:  `<a title="a &quot; b" target=_blank>x</a><a title='&apos;' target=_blank>y</a>`,
+			want: []string{
+				"t.html:1:23: warning: a element with target=_blank is missing rel=noopener [target-blank-noopener]",
+				"t.html:1:60: warning: a element with target=_blank is missing rel=noopener [target-blank-noopener]",
+			},
+		},
+		{
+			name: "redundant role",
+			src:  `<nav role=navigation></nav><a role=link>x</a><a href=/ role="link">y</a>`,
+			want: []string{
+				`t.html:1:6: warning: role "navigation" is the implicit role of the nav element [redundant-role]`,
+				`t.html:1:56: warning: role "link" is the implicit role of the a element [redundant-role]`,
+			},
+		},
+		{
+			name: "obsolete",
+			src:  `<center><table><tr><td bgcolor=red>x</td></tr></table></center>`,
+			want: []string{
+				"t.html:1:1: warning: center element is obsolete: Use CSS instead. [obsolete]",
+				"t.html:1:24: warning: bgcolor attribute on the td element is obsolete: Use CSS instead. [obsolete]",
+			},
+		},
+		{
+			name: "boolean value",
+			src:  `<div hidden></div><div hidden=hidden></div><div hidden="false"></div>`,
+			want: []string{
+				`t.html:1:49: error: boolean attribute hidden has the value "false", which does not turn it off [boolean-value]`,
+			},
+		},
+		{
+			name: "attribute value",
+			src:  "<td colspan=0>é</td>\n\t<td colspan=2>x</td>",
+			want: []string{`t.html:1:5: error: td element: attribute "colspan": 0 must be at least 1 [attribute-value]`},
+		},
+		{
+			name: "unknown element and attribute",
+			src:  `<my-element foo></my-element><blink><div foo=1 data-x=2 aria-label=x onclick="go()"></div>`,
+			want: []string{
+				"t.html:1:30: error: blink element is not defined by the spec [unknown-element]",
+				"t.html:1:42: error: foo attribute is not defined for the div element [unknown-attribute]",
+			},
+		},
+		{
+			name: "foreign content",
+			src:  `<svg><img></img></svg><img alt="">`,
+		},
+		{
+			name: "unclosed foreign children",
+			src:  `<svg><path d=""><g><g></g></svg><img>`,
+			want: []string{"t.html:1:33: error: img element is missing an alt attribute [img-alt]"},
+		},
+		{
+			name: "configured",
+			cfg:  Config{Rules: map[string]Severity{"img-alt": SeverityWarning, "boolean-value": SeverityOff}},
+			src:  `<img><div hidden=false></div>`,
+			want: []string{"t.html:1:1: warning: img element is missing an alt attribute [img-alt]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, d := range New(testSpec(), tt.cfg).Lint("t.html", []byte(tt.src)) {
+				got = append(got, d.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantErr bool
+	}{
+		{name: "valid", in: `{"rules": {"img-alt": "warning", "redundant-role": "off"}}`},
+		{name: "unknown rule", in: `{"rules": {"img-src": "off"}}`, wantErr: true},
+		{name: "unknown severity", in: `{"rules": {"img-alt": "fatal"}}`, wantErr: true},
+		{name: "unknown field", in: `{"rule": {}}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadConfig(strings.NewReader(tt.in)); (err != nil) != tt.wantErr {
+				t.Errorf("LoadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	diagnostics := New(testSpec(), Config{}).Lint("t.html", []byte("<p>\n<img>"))
+
+	var out bytes.Buffer
+	if err := WriteSARIF(&out, diagnostics); err != nil {
+		t.Fatalf("WriteSARIF() error = %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+		t.Fatalf("WriteSARIF() wrote invalid JSON: %v", err)
+	}
+	if log.Version != sarifVersion || len(log.Runs) != 1 || len(log.Runs[0].Tool.Driver.Rules) != len(rules) {
+		t.Fatalf("WriteSARIF() = %s", out.String())
+	}
+
+	results := log.Runs[0].Results
+	if len(results) != 1 || results[0].RuleID != "img-alt" || results[0].Level != "error" ||
+		results[0].Locations[0].PhysicalLocation.Region.StartLine != 2 {
+		t.Errorf("WriteSARIF() results = %+v", results)
+	}
+}
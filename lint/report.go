@@ -0,0 +1,120 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// sarifVersion is the version of the SARIF format written by WriteSARIF.
+const sarifVersion = "2.1.0"
+
+// WriteText writes one line per diagnostic in the path:line:column form that editors and CI logs understand.
+func WriteText(w io.Writer, diagnostics []Diagnostic) error {
+	for _, d := range diagnostics {
+		if _, err := fmt.Fprintln(w, d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteJSON writes the diagnostics as a JSON array.
+func WriteJSON(w io.Writer, diagnostics []Diagnostic) error {
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(diagnostics)
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region struct {
+			StartLine   int `json:"startLine"`
+			StartColumn int `json:"startColumn"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+}
+
+// WriteSARIF writes the diagnostics as a SARIF log, which code scanning tools such as GitHub's can upload.
+func WriteSARIF(w io.Writer, diagnostics []Diagnostic) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{Name: "specgen lint"},
+		},
+		Results: []sarifResult{},
+	}
+	for _, r := range rules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               r.Name,
+			ShortDescription: sarifMessage{Text: r.Description},
+		})
+	}
+
+	for _, d := range diagnostics {
+		var location sarifLocation
+		location.PhysicalLocation.ArtifactLocation.URI = d.Path
+		location.PhysicalLocation.Region.StartLine = d.Line
+		location.PhysicalLocation.Region.StartColumn = d.Column
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    d.Rule,
+			Level:     string(d.Severity),
+			Message:   sarifMessage{Text: d.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(sarifLog{
+		Version: sarifVersion,
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,342 @@
+package lint
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/go-htemel/spec"
+)
+
+// Rule is a check that the Linter runs on every start tag outside of SVG and MathML content.
+type Rule struct {
+	Name        string
+	Description string
+	// Severity is used when the Config does not set one.
+	Severity Severity
+
+	check func(r *run, t *tag)
+}
+
+var rules = []*Rule{
+	{
+		Name:        "unknown-element",
+		Description: "Elements must be defined by the spec or be custom elements, whose names contain a hyphen.",
+		Severity:    SeverityError,
+		check:       checkUnknownElement,
+	},
+	{
+		Name:        "unknown-attribute",
+		Description: "Attributes must be defined by the spec for the element, event handlers and custom elements are not checked.",
+		Severity:    SeverityError,
+		check:       checkUnknownAttribute,
+	},
+	{
+		Name:        "img-alt",
+		Description: "img elements must have an alt attribute, which is empty for decorative images.",
+		Severity:    SeverityError,
+		check:       checkImgAlt,
+	},
+	{
+		Name:        "duplicate-id",
+		Description: "id attributes must be unique within a document.",
+		Severity:    SeverityError,
+		check:       checkDuplicateID,
+	},
+	{
+		Name:        "target-blank-noopener",
+		Description: "Links opened in a new browsing context with target=_blank should have rel=noopener or rel=noreferrer.",
+		Severity:    SeverityWarning,
+		check:       checkTargetBlank,
+	},
+	{
+		Name:        "redundant-role",
+		Description: "role attributes should not repeat the element's implicit ARIA role.",
+		Severity:    SeverityWarning,
+		check:       checkRedundantRole,
+	},
+	{
+		Name:        "obsolete",
+		Description: "Obsolete elements and attributes must not be used, this needs a spec generated with obsolete features.",
+		Severity:    SeverityWarning,
+		check:       checkObsolete,
+	},
+	{
+		Name:        "boolean-value",
+		Description: "Boolean attributes must be empty or set to their own name, as any value turns them on.",
+		Severity:    SeverityError,
+		check:       checkBooleanValue,
+	},
+	{
+		Name:        "attribute-value",
+		Description: "Attribute values must match the syntax and keywords the spec allows for them.",
+		Severity:    SeverityError,
+		check:       checkAttributeValue,
+	},
+}
+
+// Rules returns the rules that a Linter runs, with their default severity.
+func Rules() []Rule {
+	out := make([]Rule, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, *r)
+	}
+
+	return out
+}
+
+func findRule(name string) (*Rule, bool) {
+	for _, r := range rules {
+		if r.Name == name {
+			return r, true
+		}
+	}
+
+	return nil, false
+}
+
+func checkUnknownElement(r *run, t *tag) {
+	if t.name == "svg" || t.name == "math" {
+		return
+	}
+
+	if _, ok := r.linter.spec.Element(t.name); !ok && !strings.Contains(t.name, "-") {
+		r.report(t.offset, "%s element is not defined by the spec", t.name)
+	}
+}
+
+func checkUnknownAttribute(r *run, t *tag) {
+	if _, ok := r.linter.spec.Element(t.name); !ok {
+		return
+	}
+
+	for i, attr := range t.attrs {
+		if strings.HasPrefix(attr.Key, "on") {
+			continue
+		}
+
+		if _, ok := r.linter.spec.LookupAttribute(t.name, attr.Key); !ok {
+			r.report(t.attrOffset(i), "%s attribute is not defined for the %s element", attr.Key, t.name)
+		}
+	}
+}
+
+func checkImgAlt(r *run, t *tag) {
+	if t.name != "img" {
+		return
+	}
+
+	if _, _, ok := t.attr("alt"); !ok {
+		r.report(t.offset, "img element is missing an alt attribute")
+	}
+}
+
+func checkDuplicateID(r *run, t *tag) {
+	attr, offset, ok := t.attr("id")
+	if !ok || attr.Val == "" || dynamic(attr.Val) {
+		return
+	}
+
+	if first, ok := r.ids[attr.Val]; ok {
+		line, _ := r.position(first)
+		r.report(offset, "id %q is already used on line %d", attr.Val, line)
+		return
+	}
+	r.ids[attr.Val] = offset
+}
+
+func checkTargetBlank(r *run, t *tag) {
+	if t.name != "a" && t.name != "area" && t.name != "form" {
+		return
+	}
+
+	target, offset, ok := t.attr("target")
+	if !ok || !strings.EqualFold(target.Val, "_blank") {
+		return
+	}
+
+	rel, _, _ := t.attr("rel")
+	if dynamic(rel.Val) {
+		return
+	}
+	for _, token := range strings.Fields(strings.ToLower(rel.Val)) {
+		if token == "noopener" || token == "noreferrer" {
+			return
+		}
+	}
+
+	r.report(offset, "%s element with target=_blank is missing rel=noopener", t.name)
+}
+
+func checkRedundantRole(r *run, t *tag) {
+	attr, offset, ok := t.attr("role")
+	if !ok || dynamic(attr.Val) {
+		return
+	}
+
+	roles := strings.Fields(strings.ToLower(attr.Val))
+	if len(roles) == 0 {
+		return
+	}
+
+	if implicit := implicitRole(t); implicit != "" && roles[0] == implicit {
+		r.report(offset, "role %q is the implicit role of the %s element", roles[0], t.name)
+	}
+}
+
+func checkObsolete(r *run, t *tag) {
+	if e, ok := r.linter.spec.Element(t.name); ok && e.Obsolete {
+		r.report(t.offset, "%s element is obsolete%s", t.name, replacement(e.Replacement))
+	}
+
+	for i, attr := range t.attrs {
+		def, ok := r.linter.spec.LookupAttribute(t.name, attr.Key)
+		if ok && def.Info().Obsolete {
+			r.report(t.attrOffset(i), "%s attribute on the %s element is obsolete%s", attr.Key, t.name,
+				replacement(def.Info().Replacement))
+		}
+	}
+}
+
+func replacement(text string) string {
+	if text == "" {
+		return ""
+	}
+
+	return ": " + text
+}
+
+func checkBooleanValue(r *run, t *tag) {
+	for i, attr := range t.attrs {
+		def, ok := r.linter.spec.LookupAttribute(t.name, attr.Key)
+		if !ok {
+			continue
+		}
+
+		if _, ok := def.(*spec.AttributeTypeBool); ok && attr.Val != "" && !dynamic(attr.Val) &&
+			!strings.EqualFold(attr.Val, attr.Key) {
+			r.report(t.attrOffset(i), "boolean attribute %s has the value %q, which does not turn it off", attr.Key,
+				attr.Val)
+		}
+	}
+}
+
+func checkAttributeValue(r *run, t *tag) {
+	for i, attr := range t.attrs {
+		if dynamic(attr.Val) {
+			continue
+		}
+
+		if err := r.linter.spec.CheckAttributeValue(t.name, attr.Key, attr.Val); err != nil {
+			r.report(t.attrOffset(i), "%s element: %v", t.name, err)
+		}
+	}
+}
+
+// implicitRoles are the implicit ARIA roles of elements that have one regardless of their attributes.
+var implicitRoles = map[string]string{
+	"article":    "article",
+	"aside":      "complementary",
+	"blockquote": "blockquote",
+	"button":     "button",
+	"code":       "code",
+	"datalist":   "listbox",
+	"dd":         "definition",
+	"del":        "deletion",
+	"details":    "group",
+	"dfn":        "term",
+	"dialog":     "dialog",
+	"dt":         "term",
+	"em":         "emphasis",
+	"fieldset":   "group",
+	"figure":     "figure",
+	"h1":         "heading",
+	"h2":         "heading",
+	"h3":         "heading",
+	"h4":         "heading",
+	"h5":         "heading",
+	"h6":         "heading",
+	"hr":         "separator",
+	"html":       "document",
+	"ins":        "insertion",
+	"li":         "listitem",
+	"main":       "main",
+	"math":       "math",
+	"menu":       "list",
+	"meter":      "meter",
+	"nav":        "navigation",
+	"ol":         "list",
+	"optgroup":   "group",
+	"option":     "option",
+	"output":     "status",
+	"p":          "paragraph",
+	"progress":   "progressbar",
+	"search":     "search",
+	"strong":     "strong",
+	"sub":        "subscript",
+	"sup":        "superscript",
+	"table":      "table",
+	"tbody":      "rowgroup",
+	"td":         "cell",
+	"textarea":   "textbox",
+	"tfoot":      "rowgroup",
+	"thead":      "rowgroup",
+	"time":       "time",
+	"tr":         "row",
+	"ul":         "list",
+}
+
+// inputRoles are the implicit ARIA roles of input elements by type, for inputs without a list attribute.
+var inputRoles = map[string]string{
+	"button":   "button",
+	"checkbox": "checkbox",
+	"email":    "textbox",
+	"image":    "button",
+	"number":   "spinbutton",
+	"radio":    "radio",
+	"range":    "slider",
+	"reset":    "button",
+	"search":   "searchbox",
+	"submit":   "button",
+	"tel":      "textbox",
+	"text":     "textbox",
+	"url":      "textbox",
+}
+
+// implicitRole returns the implicit ARIA role of the element, as given by ARIA in HTML, or an empty string if it
+// has none or it depends on more than its attributes.
+func implicitRole(t *tag) string {
+	switch t.name {
+	case "a", "area":
+		if _, _, ok := t.attr("href"); ok {
+			return "link"
+		}
+		return ""
+	case "img":
+		if alt, _, ok := t.attr("alt"); ok && alt.Val != "" {
+			return "img"
+		}
+		return ""
+	case "input":
+		if _, _, ok := t.attr("list"); ok {
+			return ""
+		}
+		typ, _, ok := t.attr("type")
+		if !ok || typ.Val == "" {
+			return "textbox"
+		}
+		if dynamic(typ.Val) {
+			return ""
+		}
+		return inputRoles[strings.ToLower(typ.Val)]
+	case "select":
+		if _, _, ok := t.attr("multiple"); ok {
+			return "listbox"
+		}
+		if size, _, ok := t.attr("size"); ok && (dynamic(size.Val) || !slices.Contains([]string{"", "0", "1"}, size.Val)) {
+			return "listbox"
+		}
+		return "combobox"
+	}
+
+	return implicitRoles[t.name]
+}
@@ -0,0 +1,217 @@
+package lint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// templStatements start the lines of a component body that hold Go statements rather than markup.
+var templStatements = []string{"if ", "else ", "} else", "for ", "switch ", "case ", "default:", "}", "//"}
+
+// LintTempl checks a templ file like Lint, treating its Go code as unknown.
+// Only the markup of templ components is checked: the declarations around them, the lines holding if, for and
+// switch statements, { } expressions and @ calls of other components are masked like the actions of LintTemplate,
+// so diagnostics point into the original file.
+// An attribute set with ?={ } is checked as a boolean attribute that is present.
+func (l *Linter) LintTempl(path string, src []byte) ([]Diagnostic, error) {
+	masked, err := maskTempl(src)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return l.Lint(path, masked), nil
+}
+
+// maskTempl returns a copy of src where everything but the markup of the templ components is masked.
+// Components start with a line beginning with "templ " and ending with "{", and end with a line holding only "}".
+func maskTempl(src []byte) ([]byte, error) {
+	out := bytes.Clone(src)
+
+	header, body := false, false
+	for i := 0; i < len(src); {
+		end := bytes.IndexByte(src[i:], '\n')
+		if end == -1 {
+			end = len(src)
+		} else {
+			end += i
+		}
+		line := bytes.TrimRight(src[i:end], " \t\r")
+
+		if !body {
+			if bytes.HasPrefix(line, []byte("templ ")) {
+				header = true
+			}
+			if header && bytes.HasSuffix(line, []byte("{")) {
+				header, body = false, true
+			}
+			blank(out, i, end)
+			i = end + 1
+			continue
+		}
+
+		if string(line) == "}" {
+			body = false
+			blank(out, i, end)
+			i = end + 1
+			continue
+		}
+
+		next, err := maskTemplLine(src, out, i)
+		if err != nil {
+			line := bytes.Count(src[:i], []byte("\n")) + 1
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		i = next
+	}
+
+	return out, nil
+}
+
+// maskTemplLine masks the Go code of the component body line starting at i, returning the offset of the next line.
+// Expressions can span lines, in which case the next line is the one after the expression ends.
+func maskTemplLine(src, out []byte, i int) (int, error) {
+	indent := i
+	for indent < len(src) && (src[indent] == ' ' || src[indent] == '\t') {
+		indent++
+	}
+
+	for _, prefix := range templStatements {
+		if bytes.HasPrefix(src[indent:], []byte(prefix)) {
+			end := bytes.IndexByte(src[indent:], '\n')
+			if end == -1 {
+				end = len(src) - indent
+			}
+			blank(out, i, indent+end)
+			return indent + end + 1, nil
+		}
+	}
+
+	for j := indent; j < len(src) && src[j] != '\n'; {
+		switch {
+		case src[j] == '{':
+			end, err := goEnd(src, j, '{', '}')
+			if err != nil {
+				return 0, err
+			}
+			blank(out, j, end)
+			out[j] = placeholder
+			j = end
+		case bytes.HasPrefix(src[j:], []byte("?={")):
+			end, err := goEnd(src, j+2, '{', '}')
+			if err != nil {
+				return 0, err
+			}
+			blank(out, j, end)
+			j = end
+		case src[j] == '@' && (j == indent || src[j-1] == '>'):
+			end, err := componentEnd(src, j)
+			if err != nil {
+				return 0, err
+			}
+			blank(out, j, end)
+			j = max(end, j+1)
+		default:
+			j++
+		}
+
+		if j >= len(src) || src[j] == '\n' {
+			return j + 1, nil
+		}
+	}
+
+	return len(src), nil
+}
+
+// componentEnd returns the offset after the @ call of a component starting at i, including the { that opens its
+// children when it ends the line, or i if the @ starts an attribute such as @click instead.
+func componentEnd(src []byte, i int) (int, error) {
+	j := i + 1
+	for j < len(src) && (isIdentByte(src[j]) || src[j] == '.') {
+		j++
+	}
+	if j == i+1 || (j < len(src) && src[j] == '=') {
+		return i, nil
+	}
+
+	if j < len(src) && src[j] == '(' {
+		end, err := goEnd(src, j, '(', ')')
+		if err != nil {
+			return 0, err
+		}
+		j = end
+	}
+
+	rest := j
+	for rest < len(src) && (src[rest] == ' ' || src[rest] == '\t') {
+		rest++
+	}
+	if rest < len(src) && src[rest] == '{' {
+		if after := bytes.TrimLeft(src[rest+1:], " \t\r"); len(after) == 0 || after[0] == '\n' {
+			return rest + 1, nil
+		}
+	}
+
+	return j, nil
+}
+
+// goEnd returns the offset just after the close byte that matches the open byte at i, skipping over the strings,
+// runes and comments of the Go code in between.
+func goEnd(src []byte, i int, open, close byte) (int, error) {
+	depth := 0
+	for i < len(src) {
+		switch {
+		case src[i] == open:
+			depth++
+			i++
+		case src[i] == close:
+			depth--
+			i++
+			if depth == 0 {
+				return i, nil
+			}
+		case bytes.HasPrefix(src[i:], []byte("/*")):
+			end := bytes.Index(src[i+2:], []byte("*/"))
+			if end == -1 {
+				return 0, errors.New("unclosed comment")
+			}
+			i += end + 4
+		case src[i] == '"' || src[i] == '\'':
+			quote := src[i]
+			i++
+			for i < len(src) && src[i] != quote && src[i] != '\n' {
+				if src[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(src) || src[i] != quote {
+				return 0, errors.New("unterminated quoted string in expression")
+			}
+			i++
+		case src[i] == '`':
+			end := bytes.IndexByte(src[i+1:], '`')
+			if end == -1 {
+				return 0, errors.New("unterminated raw string in expression")
+			}
+			i += end + 2
+		default:
+			i++
+		}
+	}
+
+	return 0, errors.New("unclosed expression")
+}
+
+// blank replaces the bytes of out between start and end with spaces, keeping newlines.
+func blank(out []byte, start, end int) {
+	for j := start; j < end && j < len(out); j++ {
+		if out[j] != '\n' {
+			out[j] = ' '
+		}
+	}
+}
+
+func isIdentByte(b byte) bool {
+	return b == '_' || '0' <= b && b <= '9' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
@@ -0,0 +1,75 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLinter_LintTempl(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "go code around components",
+			src: "package views\n\nimport \"fmt\"\n\nfunc label(n int) string {\n\treturn fmt.Sprint(n < 2, \"<blink>\")\n}\n\n" +
+				"templ Item(\n\tn int,\n) {\n\t<p class={ label(n) }>{ label(n) }</p>\n}\n",
+		},
+		{
+			name: "expressions and statements",
+			src: "templ List(items []string, open bool) {\n" +
+				"\t<div { attrs... } id={ fmt.Sprint(map[string]int{\"}\": 1}) }>\n" +
+				"\t\tfor _, item := range items {\n" +
+				"\t\t\tif len(item) < 3 {\n" +
+				"\t\t\t\t<p>{ item }</p>\n" +
+				"\t\t\t} else {\n" +
+				"\t\t\t\t<p foo=\"x\">{ item }</p>\n" +
+				"\t\t\t}\n" +
+				"\t\t}\n" +
+				"\t</div>\n" +
+				"\t<nav hidden?={ open }><a>x</a></nav>\n" +
+				"\t<input type=\"radio\" hidden?={ open }><input type=\"text\">\n" +
+				"}\n",
+			want: []string{
+				"t.templ:7:8: error: foo attribute is not defined for the p element [unknown-attribute]",
+				"t.templ:12:9: error: input element: attribute \"type\": \"radio\" is not an allowed keyword [attribute-value]",
+			},
+		},
+		{
+			name: "component calls",
+			src: "templ Page() {\n" +
+				"\t@Layout(\"title\", func() { }) {\n" +
+				"\t\t<a\n\t\t\t@click=\"go()\"\n\t\t>Go</a>\n" +
+				"\t\t<p>@Item(1)<blink></p>\n" +
+				"\t}\n" +
+				"}\n",
+			want: []string{
+				"t.templ:4:4: error: @click attribute is not defined for the a element [unknown-attribute]",
+				"t.templ:6:14: error: blink element is not defined by the spec [unknown-element]",
+			},
+		},
+		{
+			name:    "unclosed expression",
+			src:     "templ Page() {\n\t<div>{ x </div>\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics, err := New(testSpec(), Config{}).LintTempl("t.templ", []byte(tt.src))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LintTempl() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var got []string
+			for _, d := range diagnostics {
+				got = append(got, d.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LintTempl() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
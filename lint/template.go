@@ -0,0 +1,113 @@
+package lint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// placeholder starts the bytes that replace a template action. The tokenizer keeps it in tag names, attribute names
+// and attribute values, which marks the ones holding an action as not being known.
+const placeholder = '\x00'
+
+// TemplateOptions configures how LintTemplate reads templates.
+type TemplateOptions struct {
+	// LeftDelim and RightDelim delimit actions, they default to {{ and }} like in text/template.
+	LeftDelim  string
+	RightDelim string
+}
+
+// LintTemplate checks a Go html/template file like Lint, treating each action as an unknown value.
+// Actions are replaced by placeholders of the same length, so diagnostics point into the original template.
+// See LintTempl for templ files.
+func (l *Linter) LintTemplate(path string, src []byte, opts TemplateOptions) ([]Diagnostic, error) {
+	if opts.LeftDelim == "" {
+		opts.LeftDelim = "{{"
+	}
+	if opts.RightDelim == "" {
+		opts.RightDelim = "}}"
+	}
+
+	masked, err := maskActions(src, []byte(opts.LeftDelim), []byte(opts.RightDelim))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return l.Lint(path, masked), nil
+}
+
+// maskActions returns a copy of src with every action replaced by a placeholder byte followed by spaces.
+// Newlines within actions are kept so that lines and columns stay the same, and delimiters within the strings,
+// raw strings and comments of an action do not end it.
+func maskActions(src, left, right []byte) ([]byte, error) {
+	out := bytes.Clone(src)
+
+	for i := 0; i < len(src); {
+		start := bytes.Index(src[i:], left)
+		if start == -1 {
+			break
+		}
+		start += i
+
+		end, err := actionEnd(src, start+len(left), right)
+		if err != nil {
+			line := bytes.Count(src[:start], []byte("\n")) + 1
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		out[start] = placeholder
+		for j := start + 1; j < end; j++ {
+			if src[j] != '\n' {
+				out[j] = ' '
+			}
+		}
+		i = end
+	}
+
+	return out, nil
+}
+
+// actionEnd returns the offset just after the right delimiter that closes the action whose content starts at i.
+func actionEnd(src []byte, i int, right []byte) (int, error) {
+	for i < len(src) {
+		switch {
+		case bytes.HasPrefix(src[i:], right):
+			return i + len(right), nil
+		case bytes.HasPrefix(src[i:], []byte("/*")):
+			end := bytes.Index(src[i+2:], []byte("*/"))
+			if end == -1 {
+				return 0, errors.New("unclosed comment")
+			}
+			i += end + 4
+		case src[i] == '"' || src[i] == '\'':
+			quote := src[i]
+			i++
+			for i < len(src) && src[i] != quote && src[i] != '\n' {
+				if src[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(src) || src[i] != quote {
+				return 0, errors.New("unterminated quoted string in action")
+			}
+			i++
+		case src[i] == '`':
+			end := bytes.IndexByte(src[i+1:], '`')
+			if end == -1 {
+				return 0, errors.New("unterminated raw string in action")
+			}
+			i += end + 2
+		default:
+			i++
+		}
+	}
+
+	return 0, errors.New("unclosed action")
+}
+
+// dynamic reports if s holds a template action, and so is not known until the template is executed.
+func dynamic(s string) bool {
+	return strings.IndexByte(s, placeholder) != -1
+}
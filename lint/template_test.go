@@ -0,0 +1,68 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLinter_LintTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    TemplateOptions
+		src     string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "actions in values and attributes",
+			src:  `<div class="{{.Class}}" {{if .Hidden}}hidden{{end}} id="{{.ID}}"></div><div id="{{.ID}}"></div>`,
+		},
+		{
+			name: "enum value",
+			src:  `<input type="{{.Type}}"><input type=radio>`,
+			want: []string{
+				`t.tmpl:1:32: error: input element: attribute "type": "radio" is not an allowed keyword [attribute-value]`,
+			},
+		},
+		{
+			name: "dynamic tag name",
+			src:  `<h{{.Level}}>x</h{{.Level}}><blink>`,
+			want: []string{"t.tmpl:1:29: error: blink element is not defined by the spec [unknown-element]"},
+		},
+		{
+			name: "positions after multiline action",
+			src:  "{{/* a }} comment */}}{{template \"x\" `\n}}`}}\n  <img src=\"{{.Src}}\">",
+			want: []string{"t.tmpl:3:3: error: img element is missing an alt attribute [img-alt]"},
+		},
+		{
+			name: "delimiters",
+			opts: TemplateOptions{LeftDelim: "[[", RightDelim: "]]"},
+			src:  `<div foo="[[.X]]" [[.Attrs]]></div><img src="{{x}}">`,
+			want: []string{
+				"t.tmpl:1:6: error: foo attribute is not defined for the div element [unknown-attribute]",
+				"t.tmpl:1:36: error: img element is missing an alt attribute [img-alt]",
+			},
+		},
+		{
+			name:    "unclosed action",
+			src:     "<div>\n{{if .X}</div>",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics, err := New(testSpec(), Config{}).LintTemplate("t.tmpl", []byte(tt.src), tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LintTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var got []string
+			for _, d := range diagnostics {
+				got = append(got, d.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LintTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The subset of the Custom Elements Manifest schema (https://github.com/webcomponents/custom-elements-manifest)
+// needed to describe the elements of a design system.
+type manifest struct {
+	Modules []manifestModule `json:"modules"`
+}
+
+type manifestModule struct {
+	Path         string                `json:"path"`
+	Declarations []manifestDeclaration `json:"declarations"`
+	Exports      []manifestExport      `json:"exports"`
+}
+
+type manifestDeclaration struct {
+	Name        string              `json:"name"`
+	TagName     string              `json:"tagName"`
+	Summary     string              `json:"summary"`
+	Description string              `json:"description"`
+	Attributes  []manifestAttribute `json:"attributes"`
+	Slots       []manifestSlot      `json:"slots"`
+	Events      []manifestEvent     `json:"events"`
+}
+
+type manifestAttribute struct {
+	Name        string        `json:"name"`
+	Summary     string        `json:"summary"`
+	Description string        `json:"description"`
+	Type        *manifestType `json:"type"`
+}
+
+type manifestSlot struct {
+	Name        string `json:"name"`
+	Summary     string `json:"summary"`
+	Description string `json:"description"`
+}
+
+type manifestEvent struct {
+	Name        string        `json:"name"`
+	Summary     string        `json:"summary"`
+	Description string        `json:"description"`
+	Type        *manifestType `json:"type"`
+}
+
+type manifestType struct {
+	Text string `json:"text"`
+}
+
+type manifestExport struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Declaration struct {
+		Name   string `json:"name"`
+		Module string `json:"module"`
+	} `json:"declaration"`
+}
+
+// ImportCustomElementsManifest converts the custom elements declared in a custom-elements.json manifest into elements.
+// Attribute types written as unions of string literals, such as 'primary' | 'secondary', become AttributeTypeEnum,
+// boolean becomes AttributeTypeBool, number becomes AttributeTypeFloat and anything else AttributeTypeString.
+func ImportCustomElementsManifest(r io.Reader) ([]*Element, error) {
+	var m manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("custom elements manifest: %w", err)
+	}
+
+	var out []*Element
+	for _, mod := range m.Modules {
+		// Elements can be registered through an export rather than declaring a tagName.
+		definitions := make(map[string]string)
+		for _, exp := range mod.Exports {
+			if exp.Kind == "custom-element-definition" && (exp.Declaration.Module == "" || exp.Declaration.Module == mod.Path) {
+				definitions[exp.Declaration.Name] = exp.Name
+			}
+		}
+
+		for _, decl := range mod.Declarations {
+			tag := decl.TagName
+			if tag == "" {
+				tag = definitions[decl.Name]
+			}
+			if tag == "" {
+				continue
+			}
+
+			if err := ValidateCustomElementName(tag); err != nil {
+				return nil, fmt.Errorf("custom elements manifest: %s: %w", mod.Path, err)
+			}
+
+			out = append(out, manifestElement(tag, decl))
+		}
+	}
+
+	return out, nil
+}
+
+func manifestElement(tag string, decl manifestDeclaration) *Element {
+	description := firstNonEmpty(decl.Description, decl.Summary)
+	e := &Element{
+		Tag:             tag,
+		Description:     description,
+		DescriptionText: markdownText(description),
+		Text:            true,
+		CustomElement:   CustomElementAutonomous,
+	}
+
+	for _, attr := range decl.Attributes {
+		if attr.Name == "" {
+			continue
+		}
+
+		var typ string
+		if attr.Type != nil {
+			typ = attr.Type.Text
+		}
+		e.Attributes = append(e.Attributes, manifestAttributeType(attr.Name, firstNonEmpty(attr.Description, attr.Summary), typ))
+	}
+
+	for _, slot := range decl.Slots {
+		e.Slots = append(e.Slots, Slot{
+			Name:        slot.Name,
+			Description: firstNonEmpty(slot.Description, slot.Summary),
+		})
+	}
+
+	for _, event := range decl.Events {
+		if event.Name == "" {
+			continue
+		}
+
+		ev := Event{
+			Name:        event.Name,
+			Description: firstNonEmpty(event.Description, event.Summary),
+		}
+		if event.Type != nil {
+			ev.Type = event.Type.Text
+		}
+		e.Events = append(e.Events, ev)
+	}
+
+	return e
+}
+
+// manifestAttributeType maps a TypeScript type string onto the closest attribute type.
+func manifestAttributeType(name, description, typ string) Attribute {
+	var members []string
+	for _, member := range strings.Split(typ, "|") {
+		member = strings.TrimSpace(member)
+		// Optional attributes commonly include these but they are not values that can be written in markup.
+		if member == "" || member == "undefined" || member == "null" {
+			continue
+		}
+		members = append(members, member)
+	}
+
+	if len(members) == 1 {
+		switch members[0] {
+		case "boolean":
+			return &AttributeTypeBool{Name: name, Description: description}
+		case "number":
+			return &AttributeTypeFloat{Name: name, Description: description}
+		}
+	}
+
+	enum := &AttributeTypeEnum{
+		Name:        name,
+		Description: description,
+		Allowed:     make(map[string]struct{}),
+	}
+	for _, member := range members {
+		if member == "string" {
+			enum.AllowCustom = true
+			continue
+		}
+
+		value, ok := unquoteLiteral(member)
+		if !ok {
+			return &AttributeTypeString{Name: name, Description: description}
+		}
+		if value == "" {
+			enum.AllowEmpty = true
+			continue
+		}
+		enum.Allowed[value] = struct{}{}
+	}
+
+	if len(enum.Allowed) == 0 {
+		return &AttributeTypeString{Name: name, Description: description}
+	}
+
+	return enum
+}
+
+func unquoteLiteral(s string) (string, bool) {
+	if len(s) < 2 {
+		return "", false
+	}
+
+	switch q := s[0]; q {
+	case '\'', '"', '`':
+		if s[len(s)-1] == q {
+			return s[1 : len(s)-1], true
+		}
+	}
+
+	return "", false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
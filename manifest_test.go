@@ -0,0 +1,87 @@
+package spec
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestImportCustomElementsManifest(t *testing.T) {
+	doc := `{
+  "schemaVersion": "2.1.0",
+  "modules": [
+    {
+      "kind": "javascript-module",
+      "path": "src/button.js",
+      "declarations": [
+        {
+          "kind": "class",
+          "name": "DsButton",
+          "customElement": true,
+          "summary": "A **themed** button.",
+          "attributes": [
+            {"name": "variant", "type": {"text": "'primary' | 'secondary' | undefined"}},
+            {"name": "size", "type": {"text": "'small' | 'large' | string"}},
+            {"name": "disabled", "type": {"text": "boolean"}},
+            {"name": "count", "type": {"text": "number"}},
+            {"name": "label", "type": {"text": "string"}},
+            {"name": "icon"}
+          ],
+          "slots": [{"name": "", "description": "Button content"}],
+          "events": [{"name": "ds-click", "type": {"text": "CustomEvent"}}]
+        },
+        {"kind": "function", "name": "helper"}
+      ],
+      "exports": [
+        {"kind": "custom-element-definition", "name": "ds-button", "declaration": {"name": "DsButton", "module": "src/button.js"}}
+      ]
+    }
+  ]
+}`
+
+	got, err := ImportCustomElementsManifest(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("ImportCustomElementsManifest() error = %v", err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("len(ImportCustomElementsManifest()) = %d, want 1", len(got))
+	}
+
+	e := got[0]
+	if e.Tag != "ds-button" || e.Description != "A **themed** button." || e.DescriptionText != "A themed button." {
+		t.Errorf("ImportCustomElementsManifest() element = %v %q %q", e.Tag, e.Description, e.DescriptionText)
+	}
+
+	wantTypes := map[string]string{
+		"variant":  "*spec.AttributeTypeEnum",
+		"size":     "*spec.AttributeTypeEnum",
+		"disabled": "*spec.AttributeTypeBool",
+		"count":    "*spec.AttributeTypeFloat",
+		"label":    "*spec.AttributeTypeString",
+		"icon":     "*spec.AttributeTypeString",
+	}
+	for name, want := range wantTypes {
+		attr, ok := e.Attribute(name)
+		if !ok {
+			t.Errorf("ImportCustomElementsManifest() missing attribute %q", name)
+			continue
+		}
+		if got := fmt.Sprintf("%T", attr); got != want {
+			t.Errorf("ImportCustomElementsManifest() attribute %q type = %v, want %v", name, got, want)
+		}
+	}
+
+	variant, _ := e.Attribute("variant")
+	if enum := variant.(*AttributeTypeEnum); len(enum.Allowed) != 2 || enum.AllowCustom {
+		t.Errorf("ImportCustomElementsManifest() variant = %+v", enum)
+	}
+	size, _ := e.Attribute("size")
+	if enum := size.(*AttributeTypeEnum); !enum.AllowCustom {
+		t.Errorf("ImportCustomElementsManifest() size should allow custom values")
+	}
+
+	if len(e.Slots) != 1 || len(e.Events) != 1 || e.Events[0].Type != "CustomEvent" {
+		t.Errorf("ImportCustomElementsManifest() slots = %v, events = %v", e.Slots, e.Events)
+	}
+}
@@ -0,0 +1,370 @@
+package spec
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// DateTimeSyntax is one of the date and time microsyntaxes defined by the spec.
+type DateTimeSyntax string
+
+// The date and time microsyntaxes, named after the valid strings of the same name in the spec.
+const (
+	DateTimeMonth            DateTimeSyntax = "month"
+	DateTimeDate             DateTimeSyntax = "date"
+	DateTimeYearlessDate     DateTimeSyntax = "yearless-date"
+	DateTimeTime             DateTimeSyntax = "time"
+	DateTimeLocalDateTime    DateTimeSyntax = "local-date-time"
+	DateTimeTimeZoneOffset   DateTimeSyntax = "time-zone-offset"
+	DateTimeGlobalDateTime   DateTimeSyntax = "global-date-time"
+	DateTimeWeek             DateTimeSyntax = "week"
+	DateTimeDuration         DateTimeSyntax = "duration"
+	DateTimeDateOptionalTime DateTimeSyntax = "date-optional-time"
+)
+
+// dateOptionalTimePhrase is how the value column names DateTimeDateOptionalTime.
+const dateOptionalTimePhrase = "valid date string with optional time"
+
+// dateTimePhrases maps the way the spec's value column names each date and time microsyntax to the syntax.
+// The date string with optional time is matched first as its phrase contains the one of the date string.
+var dateTimePhrases = []struct {
+	phrase string
+	syntax DateTimeSyntax
+}{
+	{phrase: "valid month string", syntax: DateTimeMonth},
+	{phrase: "valid date string", syntax: DateTimeDate},
+	{phrase: "valid yearless date string", syntax: DateTimeYearlessDate},
+	{phrase: "valid time string", syntax: DateTimeTime},
+	{phrase: "valid local date and time string", syntax: DateTimeLocalDateTime},
+	{phrase: "valid time-zone offset string", syntax: DateTimeTimeZoneOffset},
+	{phrase: "valid global date and time string", syntax: DateTimeGlobalDateTime},
+	{phrase: "valid week string", syntax: DateTimeWeek},
+	{phrase: "valid duration string", syntax: DateTimeDuration},
+}
+
+// AttributeTypeURL allows for setting a valid URL, potentially surrounded by spaces, on an attribute.
+// NonEmpty field accounts for attributes that require a valid non-empty URL.
+type AttributeTypeURL struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	NonEmpty    bool   `json:"non_empty,omitempty"`
+
+	AttributeInfo
+}
+
+func (a AttributeTypeURL) isAttr() {}
+
+func (a AttributeTypeURL) GetName() string {
+	return a.Name
+}
+
+func (a AttributeTypeURL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Name          string `json:"name"`
+		Description   string `json:"description,omitempty"`
+		NonEmpty      bool   `json:"non_empty,omitempty"`
+		AttributeType string `json:"attribute_type"`
+
+		AttributeInfo
+	}{
+		Name:          a.Name,
+		Description:   a.Description,
+		NonEmpty:      a.NonEmpty,
+		AttributeType: "AttributeTypeURL",
+		AttributeInfo: a.AttributeInfo,
+	})
+}
+
+// AttributeTypeURLList allows for setting space-separated valid URLs on an attribute, e.g. ping.
+type AttributeTypeURLList struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	AttributeInfo
+}
+
+func (a AttributeTypeURLList) isAttr() {}
+
+func (a AttributeTypeURLList) GetName() string {
+	return a.Name
+}
+
+func (a AttributeTypeURLList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Name          string `json:"name"`
+		Description   string `json:"description,omitempty"`
+		AttributeType string `json:"attribute_type"`
+
+		AttributeInfo
+	}{
+		Name:          a.Name,
+		Description:   a.Description,
+		AttributeType: "AttributeTypeURLList",
+		AttributeInfo: a.AttributeInfo,
+	})
+}
+
+// AttributeTypeDateTime allows for setting date and time values on an attribute.
+// Syntaxes field holds the date and time microsyntaxes that the value may use.
+// AllowNumber field accounts for attributes that also take a number, such as the min and max attributes of input,
+// whose syntax depends on the input's type, and the datetime attribute of time, which can be a year.
+type AttributeTypeDateTime struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Syntaxes    []DateTimeSyntax `json:"syntaxes"`
+	AllowNumber bool             `json:"allow_number,omitempty"`
+
+	AttributeInfo
+}
+
+func (a AttributeTypeDateTime) isAttr() {}
+
+func (a AttributeTypeDateTime) GetName() string {
+	return a.Name
+}
+
+func (a AttributeTypeDateTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Name          string           `json:"name"`
+		Description   string           `json:"description,omitempty"`
+		Syntaxes      []DateTimeSyntax `json:"syntaxes"`
+		AllowNumber   bool             `json:"allow_number,omitempty"`
+		AttributeType string           `json:"attribute_type"`
+
+		AttributeInfo
+	}{
+		Name:          a.Name,
+		Description:   a.Description,
+		Syntaxes:      a.Syntaxes,
+		AllowNumber:   a.AllowNumber,
+		AttributeType: "AttributeTypeDateTime",
+		AttributeInfo: a.AttributeInfo,
+	})
+}
+
+// AttributeTypeMIME allows for setting a valid MIME type string on an attribute.
+// List field accounts for attributes such as accept that take comma-separated MIME types.
+// Keywords field holds the values allowed instead of a MIME type, such as module for the type attribute of script.
+type AttributeTypeMIME struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	List        bool     `json:"list,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+
+	AttributeInfo
+}
+
+func (a AttributeTypeMIME) isAttr() {}
+
+func (a AttributeTypeMIME) GetName() string {
+	return a.Name
+}
+
+func (a AttributeTypeMIME) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Name          string   `json:"name"`
+		Description   string   `json:"description,omitempty"`
+		List          bool     `json:"list,omitempty"`
+		Keywords      []string `json:"keywords,omitempty"`
+		AttributeType string   `json:"attribute_type"`
+
+		AttributeInfo
+	}{
+		Name:          a.Name,
+		Description:   a.Description,
+		List:          a.List,
+		Keywords:      a.Keywords,
+		AttributeType: "AttributeTypeMIME",
+		AttributeInfo: a.AttributeInfo,
+	})
+}
+
+// AttributeTypeMediaQuery allows for setting a valid media query list on an attribute.
+type AttributeTypeMediaQuery struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	AttributeInfo
+}
+
+func (a AttributeTypeMediaQuery) isAttr() {}
+
+func (a AttributeTypeMediaQuery) GetName() string {
+	return a.Name
+}
+
+func (a AttributeTypeMediaQuery) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Name          string `json:"name"`
+		Description   string `json:"description,omitempty"`
+		AttributeType string `json:"attribute_type"`
+
+		AttributeInfo
+	}{
+		Name:          a.Name,
+		Description:   a.Description,
+		AttributeType: "AttributeTypeMediaQuery",
+		AttributeInfo: a.AttributeInfo,
+	})
+}
+
+// AttributeTypeColor allows for setting a color on an attribute.
+// By default the value is a valid simple color such as #ff8000, CSS field accounts for attributes that take
+// any CSS color.
+type AttributeTypeColor struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CSS         bool   `json:"css,omitempty"`
+
+	AttributeInfo
+}
+
+func (a AttributeTypeColor) isAttr() {}
+
+func (a AttributeTypeColor) GetName() string {
+	return a.Name
+}
+
+func (a AttributeTypeColor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Name          string `json:"name"`
+		Description   string `json:"description,omitempty"`
+		CSS           bool   `json:"css,omitempty"`
+		AttributeType string `json:"attribute_type"`
+
+		AttributeInfo
+	}{
+		Name:          a.Name,
+		Description:   a.Description,
+		CSS:           a.CSS,
+		AttributeType: "AttributeTypeColor",
+		AttributeInfo: a.AttributeInfo,
+	})
+}
+
+// AttributeTypeSrcset allows for setting comma-separated image candidate strings on an attribute.
+type AttributeTypeSrcset struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	AttributeInfo
+}
+
+func (a AttributeTypeSrcset) isAttr() {}
+
+func (a AttributeTypeSrcset) GetName() string {
+	return a.Name
+}
+
+func (a AttributeTypeSrcset) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Name          string `json:"name"`
+		Description   string `json:"description,omitempty"`
+		AttributeType string `json:"attribute_type"`
+
+		AttributeInfo
+	}{
+		Name:          a.Name,
+		Description:   a.Description,
+		AttributeType: "AttributeTypeSrcset",
+		AttributeInfo: a.AttributeInfo,
+	})
+}
+
+// AttributeTypeSizes allows for setting a valid source size list on an attribute.
+type AttributeTypeSizes struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	AttributeInfo
+}
+
+func (a AttributeTypeSizes) isAttr() {}
+
+func (a AttributeTypeSizes) GetName() string {
+	return a.Name
+}
+
+func (a AttributeTypeSizes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Name          string `json:"name"`
+		Description   string `json:"description,omitempty"`
+		AttributeType string `json:"attribute_type"`
+
+		AttributeInfo
+	}{
+		Name:          a.Name,
+		Description:   a.Description,
+		AttributeType: "AttributeTypeSizes",
+		AttributeInfo: a.AttributeInfo,
+	})
+}
+
+// typedAttribute returns attr converted to the attribute type for the microsyntax named in value, the text of
+// its "Value" column in the attribute index.
+// Only string and number attributes are converted, as they are the types used when the spec's syntax was not
+// known while writing the attributes by hand.
+func typedAttribute(attr Attribute, value string) Attribute {
+	var name, description string
+	var info AttributeInfo
+	switch a := attr.(type) {
+	case *AttributeTypeString:
+		name, description, info = a.Name, a.Description, a.AttributeInfo
+	case *AttributeTypeNumber:
+		name, description, info = a.Name, a.Description, a.AttributeInfo
+	default:
+		return attr
+	}
+
+	value = strings.ToLower(strings.Join(strings.Fields(value), " "))
+
+	switch {
+	case strings.Contains(value, "image candidate"):
+		return &AttributeTypeSrcset{Name: name, Description: description, AttributeInfo: info}
+	case strings.Contains(value, "source size"):
+		return &AttributeTypeSizes{Name: name, Description: description, AttributeInfo: info}
+	case strings.Contains(value, "media query"):
+		return &AttributeTypeMediaQuery{Name: name, Description: description, AttributeInfo: info}
+	case strings.Contains(value, "simple color"):
+		return &AttributeTypeColor{Name: name, Description: description, AttributeInfo: info}
+	case strings.Contains(value, "css <color>"):
+		return &AttributeTypeColor{Name: name, Description: description, CSS: true, AttributeInfo: info}
+	case strings.Contains(value, "mime type"):
+		return &AttributeTypeMIME{Name: name, Description: description, List: strings.Contains(value, "comma-separated"), AttributeInfo: info}
+	case strings.Contains(value, "url") && (strings.Contains(value, "tokens") || strings.Contains(value, "list")):
+		return &AttributeTypeURLList{Name: name, Description: description, AttributeInfo: info}
+	case strings.Contains(value, "url"):
+		return &AttributeTypeURL{Name: name, Description: description, NonEmpty: strings.Contains(value, "non-empty"), AttributeInfo: info}
+	}
+
+	if syntaxes := dateTimeSyntaxes(value); len(syntaxes) > 0 {
+		return &AttributeTypeDateTime{
+			Name:          name,
+			Description:   description,
+			Syntaxes:      syntaxes,
+			AllowNumber:   strings.Contains(value, "number") || strings.Contains(value, "integer"),
+			AttributeInfo: info,
+		}
+	}
+
+	return attr
+}
+
+// dateTimeSyntaxes returns the date and time microsyntaxes named in value.
+func dateTimeSyntaxes(value string) []DateTimeSyntax {
+	var out []DateTimeSyntax
+
+	if strings.Contains(value, dateOptionalTimePhrase) {
+		out = append(out, DateTimeDateOptionalTime)
+		value = strings.ReplaceAll(value, dateOptionalTimePhrase, "")
+	}
+
+	for _, p := range dateTimePhrases {
+		if strings.Contains(value, p.phrase) && !slices.Contains(out, p.syntax) {
+			out = append(out, p.syntax)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,142 @@
+package spec
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_typedAttribute(t *testing.T) {
+	tests := []struct {
+		name  string
+		attr  Attribute
+		value string
+		want  Attribute
+	}{
+		{
+			name:  "url",
+			attr:  &AttributeTypeString{Name: "href", Description: "Address of the hyperlink"},
+			value: "Valid URL potentially surrounded by spaces",
+			want:  &AttributeTypeURL{Name: "href", Description: "Address of the hyperlink"},
+		},
+		{
+			name:  "non-empty url",
+			attr:  &AttributeTypeString{Name: "action"},
+			value: "Valid non-empty URL potentially surrounded by spaces",
+			want:  &AttributeTypeURL{Name: "action", NonEmpty: true},
+		},
+		{
+			name:  "url list",
+			attr:  &AttributeTypeString{Name: "ping"},
+			value: "Set of space-separated tokens consisting of valid non-empty URLs",
+			want:  &AttributeTypeURLList{Name: "ping"},
+		},
+		{
+			name:  "date with optional time",
+			attr:  &AttributeTypeString{Name: "datetime"},
+			value: "Valid date string with optional time",
+			want:  &AttributeTypeDateTime{Name: "datetime", Syntaxes: []DateTimeSyntax{DateTimeDateOptionalTime}},
+		},
+		{
+			name:  "time datetime",
+			attr:  &AttributeTypeString{Name: "datetime"},
+			value: "Valid month string, valid date string, valid yearless date string, valid time string, valid local date and time string, valid time-zone offset string, valid global date and time string, valid week string, valid non-negative integer, or valid duration string",
+			want: &AttributeTypeDateTime{Name: "datetime", Syntaxes: []DateTimeSyntax{
+				DateTimeMonth, DateTimeDate, DateTimeYearlessDate, DateTimeTime, DateTimeLocalDateTime,
+				DateTimeTimeZoneOffset, DateTimeGlobalDateTime, DateTimeWeek, DateTimeDuration,
+			}, AllowNumber: true},
+		},
+		{
+			name:  "mime type",
+			attr:  &AttributeTypeString{Name: "type"},
+			value: "Valid MIME type string",
+			want:  &AttributeTypeMIME{Name: "type"},
+		},
+		{
+			name:  "mime type list",
+			attr:  &AttributeTypeString{Name: "accept"},
+			value: "Set of comma-separated tokens* consisting of valid MIME type strings with no parameters or audio/*, video/*, or image/*",
+			want:  &AttributeTypeMIME{Name: "accept", List: true},
+		},
+		{
+			name:  "media query",
+			attr:  &AttributeTypeString{Name: "media"},
+			value: "Valid media query list",
+			want:  &AttributeTypeMediaQuery{Name: "media"},
+		},
+		{
+			name:  "css color",
+			attr:  &AttributeTypeString{Name: "color"},
+			value: "CSS <color>",
+			want:  &AttributeTypeColor{Name: "color", CSS: true},
+		},
+		{
+			name:  "srcset",
+			attr:  &AttributeTypeString{Name: "srcset"},
+			value: "Comma-separated list of image candidate strings",
+			want:  &AttributeTypeSrcset{Name: "srcset"},
+		},
+		{
+			name:  "sizes",
+			attr:  &AttributeTypeString{Name: "sizes"},
+			value: "Valid source size list",
+			want:  &AttributeTypeSizes{Name: "sizes"},
+		},
+		{
+			name:  "keeps security class",
+			attr:  &AttributeTypeString{Name: "src", AttributeInfo: AttributeInfo{Security: SecurityURL}},
+			value: "Valid non-empty URL potentially surrounded by spaces",
+			want:  &AttributeTypeURL{Name: "src", NonEmpty: true, AttributeInfo: AttributeInfo{Security: SecurityURL}},
+		},
+		{
+			name:  "text is unchanged",
+			attr:  &AttributeTypeString{Name: "title"},
+			value: "Text",
+			want:  &AttributeTypeString{Name: "title"},
+		},
+		{
+			name:  "sst is unchanged",
+			attr:  &AttributeTypeSST{Name: "sizes"},
+			value: "Unordered set of unique space-separated tokens, ASCII case-insensitive, consisting of sizes",
+			want:  &AttributeTypeSST{Name: "sizes"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := typedAttribute(tt.attr, tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("typedAttribute() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMicrosyntaxAttributes_JSON(t *testing.T) {
+	want := &Spec{
+		Name:     "test",
+		Elements: []*Element{},
+		Attributes: []Attribute{
+			&AttributeTypeURL{Name: "href", NonEmpty: true, AttributeInfo: AttributeInfo{Security: SecurityURL}},
+			&AttributeTypeURLList{Name: "ping"},
+			&AttributeTypeDateTime{Name: "min", Syntaxes: []DateTimeSyntax{DateTimeDate}, AllowNumber: true},
+			&AttributeTypeMIME{Name: "accept", List: true},
+			&AttributeTypeMIME{Name: "type", Keywords: []string{"module", "importmap"}},
+			&AttributeTypeMediaQuery{Name: "media"},
+			&AttributeTypeColor{Name: "color", CSS: true},
+			&AttributeTypeSrcset{Name: "srcset"},
+			&AttributeTypeSizes{Name: "sizes"},
+		},
+	}
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	got := &Spec{}
+	if err = json.Unmarshal(b, got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmarshal(%s) = %#v, want %#v", b, got.Attributes, want.Attributes)
+	}
+}
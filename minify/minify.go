@@ -0,0 +1,296 @@
+// Package minify renders html.Node trees as compactly as a Spec allows.
+package minify
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/go-htemel/spec"
+	"github.com/go-htemel/spec/internal/htmltext"
+	"golang.org/x/net/html"
+)
+
+// rawTextTags have their text content written as is on top of the elements with the raw text kind,
+// as the parser treats the content of these legacy and fallback elements as raw text too.
+var rawTextTags = []string{"script", "style", "xmp", "iframe", "noembed", "noframes", "noscript", "plaintext"}
+
+// preserveTags keep the whitespace of their content.
+var preserveTags = []string{"pre", "textarea", "listing"}
+
+// noTextTags have a content model that cannot hold text, so the whitespace between their children is dropped.
+// Whitespace anywhere else separates the words around it and is collapsed to a single space instead.
+var noTextTags = []string{
+	"html", "head", "table", "thead", "tbody", "tfoot", "tr", "colgroup", "ul", "ol", "menu", "dl",
+	"select", "optgroup", "hgroup", "picture", "frameset",
+}
+
+// unquotedForbidden holds the characters that cannot appear in an unquoted attribute value.
+const unquotedForbidden = " \t\n\f\r\"'=<>`"
+
+// Options controls the optional behavior of a Minifier.
+type Options struct {
+	// KeepComments writes comments instead of dropping them.
+	KeepComments bool
+	// OmitOptionalTags drops end tags that the spec's tag omission rules allow to be left out.
+	OmitOptionalTags bool
+}
+
+// Minifier renders html.Node trees using the spec to decide what can safely be left out.
+type Minifier struct {
+	spec     *spec.Spec
+	elements map[string]*spec.Element
+	opts     Options
+}
+
+// New returns a Minifier for documents conforming to sp.
+func New(sp *spec.Spec, opts Options) *Minifier {
+	elements := make(map[string]*spec.Element, len(sp.Elements))
+	for _, e := range sp.Elements {
+		elements[e.Tag] = e
+	}
+
+	return &Minifier{
+		spec:     sp,
+		elements: elements,
+		opts:     opts,
+	}
+}
+
+// Render writes the minified form of n to w.
+// Values of boolean attributes, attributes set to their missing value default, quotes that the value syntax
+// does not need, end tags of void elements and whitespace between the children of elements that cannot hold text
+// are all dropped. Other runs of whitespace outside of pre and textarea are collapsed to a single space.
+func (m *Minifier) Render(w io.Writer, n *html.Node) error {
+	bw := bufio.NewWriter(w)
+	m.render(bw, n, false)
+
+	return bw.Flush()
+}
+
+func (m *Minifier) render(w *bufio.Writer, n *html.Node, preserve bool) {
+	switch n.Type {
+	case html.DocumentNode:
+		m.renderChildren(w, n, preserve)
+	case html.DoctypeNode:
+		w.WriteString("<!doctype " + n.Data + ">")
+	case html.CommentNode:
+		w.WriteString("<!--" + n.Data + "-->")
+	case html.TextNode:
+		m.renderText(w, n, preserve)
+	case html.ElementNode:
+		m.renderElement(w, n, preserve)
+	}
+}
+
+func (m *Minifier) renderChildren(w *bufio.Writer, n *html.Node, preserve bool) {
+	for child := range n.ChildNodes() {
+		if !m.dropped(child) {
+			m.render(w, child, preserve)
+		}
+	}
+}
+
+func (m *Minifier) renderText(w *bufio.Writer, n *html.Node, preserve bool) {
+	if n.Parent != nil && m.rawText(n.Parent) {
+		w.WriteString(n.Data)
+		return
+	}
+
+	text := n.Data
+	if !preserve {
+		text = htmltext.CollapseWhitespace(text)
+	}
+	w.WriteString(escapeText(text))
+}
+
+func (m *Minifier) renderElement(w *bufio.Writer, n *html.Node, preserve bool) {
+	w.WriteString("<" + qualifiedName(n.Namespace, n.Data))
+	unquoted := false
+	for _, attr := range n.Attr {
+		unquoted = m.renderAttribute(w, n, attr)
+	}
+
+	e, known := m.elements[n.Data]
+	if n.Namespace == "" && known && (e.Void || e.Kind == spec.ElementKindVoid) {
+		w.WriteString(">")
+		return
+	}
+
+	if n.Namespace != "" && n.FirstChild == nil {
+		// The slash would be read as part of an unquoted value, so it is separated from it.
+		if unquoted {
+			w.WriteString(" ")
+		}
+		w.WriteString("/>")
+		return
+	}
+	w.WriteString(">")
+
+	preserve = preserve || (n.Namespace == "" && slices.Contains(preserveTags, n.Data))
+	// The parser drops a newline directly after these start tags, so one has to be added back to keep a leading newline.
+	if n.Namespace == "" && slices.Contains(preserveTags, n.Data) && n.FirstChild != nil &&
+		n.FirstChild.Type == html.TextNode && strings.HasPrefix(n.FirstChild.Data, "\n") {
+		w.WriteString("\n")
+	}
+
+	m.renderChildren(w, n, preserve)
+
+	if m.opts.OmitOptionalTags && n.Namespace == "" && known && m.endTagOmissible(n, e) {
+		return
+	}
+	w.WriteString("</" + qualifiedName(n.Namespace, n.Data) + ">")
+}
+
+// renderAttribute writes attr, reporting if it ends with an unquoted value.
+func (m *Minifier) renderAttribute(w *bufio.Writer, n *html.Node, attr html.Attribute) bool {
+	name := qualifiedName(attr.Namespace, attr.Key)
+
+	if n.Namespace == "" && attr.Namespace == "" {
+		if def, ok := m.spec.LookupAttribute(n.Data, attr.Key); ok {
+			switch a := def.(type) {
+			case *spec.AttributeTypeBool:
+				w.WriteString(" " + name)
+				return false
+			case *spec.AttributeTypeEnum:
+				if a.MissingDefault != "" && strings.EqualFold(attr.Val, a.MissingDefault) {
+					return false
+				}
+			}
+		}
+	}
+
+	w.WriteString(" " + name + "=")
+	if attr.Val != "" && !strings.ContainsAny(attr.Val, unquotedForbidden) {
+		w.WriteString(strings.ReplaceAll(attr.Val, "&", "&amp;"))
+		return true
+	}
+
+	w.WriteString(`"` + strings.NewReplacer("&", "&amp;", `"`, "&quot;").Replace(attr.Val) + `"`)
+	return false
+}
+
+// rawText reports if the text content of n is written without escaping.
+func (m *Minifier) rawText(n *html.Node) bool {
+	if n.Namespace != "" {
+		return false
+	}
+	if e, ok := m.elements[n.Data]; ok && e.Kind == spec.ElementKindRawText {
+		return true
+	}
+
+	return slices.Contains(rawTextTags, n.Data)
+}
+
+// dropped reports if n is left out of the output entirely.
+func (m *Minifier) dropped(n *html.Node) bool {
+	switch n.Type {
+	case html.CommentNode:
+		return !m.opts.KeepComments
+	case html.TextNode:
+		// Whitespace between the children of elements that cannot hold text, such as ul and table, is insignificant.
+		if strings.TrimSpace(n.Data) != "" || n.Parent == nil {
+			return false
+		}
+		if n.Parent.Type == html.DocumentNode {
+			return true
+		}
+
+		return n.Parent.Namespace == "" && slices.Contains(noTextTags, n.Parent.Data)
+	}
+
+	return false
+}
+
+// nextRendered returns the sibling after n that is written to the output.
+func (m *Minifier) nextRendered(n *html.Node) *html.Node {
+	next := n.NextSibling
+	for next != nil && m.dropped(next) {
+		next = next.NextSibling
+	}
+
+	return next
+}
+
+func (m *Minifier) endTagOmissible(n *html.Node, e *spec.Element) bool {
+	if e.TagOmission == nil || e.TagOmission.End == nil {
+		return false
+	}
+
+	next := m.nextRendered(n)
+	for _, cond := range e.TagOmission.End.Conditions {
+		if m.endConditionMet(n, next, cond) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *Minifier) endConditionMet(n, next *html.Node, cond spec.OmissionCondition) bool {
+	// Conditions about the element's content only apply to start tags.
+	if cond.Empty || len(cond.FirstChild) > 0 || len(cond.FirstChildNot) > 0 {
+		return false
+	}
+
+	if len(cond.FollowedBy) > 0 && (next == nil || !matchesOmission(next, cond.FollowedBy)) {
+		return false
+	}
+	if next != nil && matchesOmission(next, cond.NotFollowedBy) {
+		return false
+	}
+	if cond.LastInParent {
+		if next != nil {
+			return false
+		}
+		if n.Parent == nil || n.Parent.Type != html.ElementNode || matchesOmission(n.Parent, cond.ParentNot) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesOmission reports if n is one of the element tags or special names used in omission conditions.
+func matchesOmission(n *html.Node, names []string) bool {
+	for _, name := range names {
+		switch name {
+		case spec.OmissionComment:
+			if n.Type == html.CommentNode {
+				return true
+			}
+		case spec.OmissionWhitespace:
+			if n.Type == html.TextNode && strings.IndexAny(n.Data, " \t\n\f\r") == 0 {
+				return true
+			}
+		case spec.OmissionElement:
+			if n.Type == html.ElementNode {
+				return true
+			}
+		case spec.OmissionCustomElement:
+			if n.Type == html.ElementNode && n.Namespace == "" && strings.Contains(n.Data, "-") {
+				return true
+			}
+		default:
+			if n.Type == html.ElementNode && n.Namespace == "" && n.Data == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func qualifiedName(namespace, name string) string {
+	switch namespace {
+	case "", "svg", "math":
+		return name
+	}
+
+	return namespace + ":" + name
+}
+
+func escapeText(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;").Replace(s)
+}
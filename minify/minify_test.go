@@ -0,0 +1,127 @@
+package minify
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-htemel/spec"
+	"golang.org/x/net/html"
+)
+
+func testSpec() *spec.Spec {
+	return &spec.Spec{
+		Name: "HTML",
+		Attributes: []spec.Attribute{
+			&spec.AttributeTypeString{Name: "class"},
+			&spec.AttributeTypeBool{Name: "hidden"},
+		},
+		Elements: []*spec.Element{
+			{Tag: "html"},
+			{Tag: "head"},
+			{Tag: "body", Text: true},
+			{Tag: "p", Text: true},
+			{Tag: "pre", Text: true},
+			{Tag: "br", Void: true},
+			{Tag: "ul"},
+			{Tag: "form"},
+			{Tag: "h2"},
+			{
+				Tag:  "li",
+				Text: true,
+				TagOmission: &spec.TagOmission{End: &spec.OmissionRule{Conditions: []spec.OmissionCondition{
+					{FollowedBy: []string{"li"}},
+					{LastInParent: true},
+				}}},
+			},
+			{
+				Tag:  "input",
+				Void: true,
+				Attributes: []spec.Attribute{
+					&spec.AttributeTypeBool{Name: "disabled"},
+					&spec.AttributeTypeEnum{
+						Name:           "type",
+						MissingDefault: "text",
+						Allowed:        map[string]struct{}{"text": {}, "checkbox": {}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestMinifier_Render(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		in   string
+		want string
+	}{
+		{
+			name: "attributes",
+			in:   `<input type="TEXT" disabled="disabled" class="a b"><input type="checkbox" class="single" hidden="">`,
+			want: `<html><head></head><body><input disabled class="a b"><input type=checkbox class=single hidden></body></html>`,
+		},
+		{
+			name: "void end tags",
+			in:   `<p>one<br></br>two</p>`,
+			want: `<html><head></head><body><p>one<br><br>two</p></body></html>`,
+		},
+		{
+			name: "whitespace",
+			in:   "<p>  lots \n\t of   space </p><pre>\n\n  keep   this\n</pre>\n<ul>\n  <li>a</li>\n  <li>b</li>\n</ul><!-- gone -->",
+			want: "<html><head></head><body><p> lots of space </p><pre>\n\n  keep   this\n</pre> <ul><li>a</li><li>b</li></ul></body></html>",
+		},
+		{
+			name: "whitespace between phrasing children",
+			in:   "<form><label>Name</label>\n  <input></form><h2><a>one</a> <b>two</b></h2>",
+			want: "<html><head></head><body><form><label>Name</label> <input></form><h2><a>one</a> <b>two</b></h2></body></html>",
+		},
+		{
+			name: "omit optional tags",
+			opts: Options{OmitOptionalTags: true, KeepComments: true},
+			in:   "<ul><li>a</li><li>b</li></ul><ul><li>c</li><!-- keep --></ul>",
+			want: "<html><head></head><body><ul><li>a<li>b</ul><ul><li>c</li><!-- keep --></ul></body></html>",
+		},
+		{
+			name: "escaping",
+			in:   `<p class='say "hi"'>a &lt; b &amp; c</p><script>if (a < b) {}</script>`,
+			want: `<html><head></head><body><p class="say &quot;hi&quot;">a &lt; b &amp; c</p><script>if (a < b) {}</script></body></html>`,
+		},
+		{
+			name: "self-closing foreign elements",
+			in:   `<svg><path d="M0"/><circle r="1" fill="a b"/><g/></svg>`,
+			want: `<html><head></head><body><svg><path d=M0 /><circle r=1 fill="a b"/><g/></svg></body></html>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(tt.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			if err = New(testSpec(), tt.opts).Render(&buf, doc); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+
+			// The output must parse back to the same tree.
+			reparsed, err := html.Parse(strings.NewReader(buf.String()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var again bytes.Buffer
+			if err = New(testSpec(), tt.opts).Render(&again, reparsed); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if again.String() != buf.String() {
+				t.Errorf("Render() of the output = %q, want %q", again.String(), buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package spec
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// obsoleteFeature is an entry of the lists of non-conforming features in the spec's obsolete features chapter.
+type obsoleteFeature struct {
+	// attribute is the obsolete attribute, or empty when the entry is for the elements in tags.
+	attribute string
+	// tags are the obsolete elements, or the elements the attribute is obsolete on.
+	tags        []string
+	replacement string
+	// url links to the entry, if it has an id.
+	url string
+}
+
+// parseObsoleteFeatures reads a dl listing obsolete elements or attributes.
+// Element entries are terms such as "applet", attribute entries are terms such as "align on caption, col elements".
+// The definitions following a group of terms give the replacement for all of them, e.g. "Use CSS instead.".
+func parseObsoleteFeatures(dl *html.Node) []obsoleteFeature {
+	var out []obsoleteFeature
+
+	var terms []*html.Node
+	var replacement []string
+	flush := func() {
+		for _, dt := range terms {
+			text := gatherCodeText(dt)
+			url := ""
+			if id, ok := firstID(dt); ok {
+				url = "#" + id
+			}
+			attrs, tags, isAttr := strings.Cut(text, " on ")
+			if !isAttr {
+				if tags := codeSpans(text); len(tags) > 0 {
+					out = append(out, obsoleteFeature{tags: tags, replacement: strings.Join(replacement, " "), url: url})
+				}
+				continue
+			}
+
+			for _, attr := range codeSpans(attrs) {
+				out = append(out, obsoleteFeature{
+					attribute:   attr,
+					tags:        codeSpans(tags),
+					replacement: strings.Join(replacement, " "),
+					url:         url,
+				})
+			}
+		}
+
+		terms = terms[:0]
+		replacement = replacement[:0]
+	}
+
+	for child := range dl.ChildNodes() {
+		if child.Type != html.ElementNode {
+			continue
+		}
+
+		switch child.Data {
+		case "dt":
+			if len(replacement) > 0 {
+				flush()
+			}
+			terms = append(terms, child)
+		case "dd":
+			if text := strings.Join(strings.Fields(rawText(child)), " "); text != "" {
+				replacement = append(replacement, text)
+			}
+		}
+	}
+	flush()
+
+	return out
+}
+
+// addObsolete adds the obsolete features to the spec, flagged as Obsolete along with their replacement.
+// Obsolete elements are added as normal elements that allow text, obsolete attributes are added as string
+// attributes to the elements they were used on, or to the global attributes when no elements are named.
+func (sp *Spec) addObsolete(features []obsoleteFeature, values valueIndex) {
+	for _, f := range features {
+		if f.attribute != "" {
+			continue
+		}
+
+		for _, tag := range f.tags {
+			if e, ok := sp.Element(tag); ok {
+				e.Obsolete = true
+				e.Replacement = f.replacement
+				continue
+			}
+
+			sp.Elements = append(sp.Elements, &Element{
+				Tag:         tag,
+				Text:        true,
+				Kind:        ElementKindNormal,
+				URL:         f.url,
+				Obsolete:    true,
+				Replacement: f.replacement,
+			})
+		}
+	}
+
+	for _, f := range features {
+		if f.attribute == "" {
+			continue
+		}
+
+		if len(f.tags) == 0 {
+			sp.Attributes = addObsoleteAttribute(sp.Attributes, "", f, values)
+			continue
+		}
+		for _, tag := range f.tags {
+			if e, ok := sp.Element(tag); ok {
+				e.Attributes = addObsoleteAttribute(e.Attributes, tag, f, values)
+			}
+		}
+	}
+}
+
+func addObsoleteAttribute(attrs []Attribute, tag string, f obsoleteFeature, values valueIndex) []Attribute {
+	attr := Attribute(&AttributeTypeString{Name: f.attribute})
+	if idx := attributeIndex(attrs, f.attribute); idx != -1 {
+		attr = attrs[idx]
+	} else {
+		attrs = append(attrs, attr)
+	}
+
+	info := attr.Info()
+	info.Obsolete = true
+	info.Replacement = f.replacement
+	if info.Security == "" {
+		info.Security = securityClass(values, tag, attr)
+	}
+	if info.URL == "" {
+		info.URL = f.url
+	}
+
+	return attrs
+}
+
+// firstID returns the first id of node or its descendants.
+func firstID(node *html.Node) (string, bool) {
+	if id, ok := getAttribute(node.Attr, "id"); ok {
+		return id, true
+	}
+
+	for n := range node.Descendants() {
+		if n.Type != html.ElementNode {
+			continue
+		}
+		if id, ok := getAttribute(n.Attr, "id"); ok {
+			return id, true
+		}
+	}
+
+	return "", false
+}
+
+// RemoveObsolete removes the obsolete elements and attributes from the spec, leaving the features that
+// can be used in conforming documents.
+func (sp *Spec) RemoveObsolete() {
+	sp.Filter(func(e *Element) bool {
+		return !e.Obsolete
+	}, func(attr Attribute) bool {
+		return !attr.Info().Obsolete
+	})
+}
@@ -0,0 +1,201 @@
+package spec
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Special names used in omission conditions alongside element tags.
+const (
+	// OmissionComment stands for a comment node.
+	OmissionComment = "#comment"
+	// OmissionWhitespace stands for a text node starting with ASCII whitespace.
+	OmissionWhitespace = "#whitespace"
+	// OmissionElement stands for any element.
+	OmissionElement = "#element"
+	// OmissionCustomElement stands for any autonomous custom element.
+	OmissionCustomElement = "#custom-element"
+)
+
+// TagOmission describes when the start and end tags of an element can be left out of a text/html document.
+type TagOmission struct {
+	// NoEndTag is set for void elements, which never have an end tag.
+	NoEndTag bool          `json:"no_end_tag,omitempty"`
+	Start    *OmissionRule `json:"start,omitempty"`
+	End      *OmissionRule `json:"end,omitempty"`
+}
+
+// OmissionRule describes when a tag can be omitted.
+// The tag can be omitted when any one of the conditions is met.
+type OmissionRule struct {
+	// Text is the rule as written in the spec.
+	Text       string              `json:"text"`
+	Conditions []OmissionCondition `json:"conditions,omitempty"`
+}
+
+// OmissionCondition holds the requirements that must all be met for a tag to be omitted.
+// Lists may contain element tags as well as the special Omission names such as OmissionComment.
+type OmissionCondition struct {
+	// Empty requires the element to have no content.
+	Empty bool `json:"empty,omitempty"`
+	// FirstChild requires the first thing inside the element to be one of these.
+	FirstChild []string `json:"first_child,omitempty"`
+	// FirstChildNot requires the first thing inside the element to be none of these.
+	FirstChildNot []string `json:"first_child_not,omitempty"`
+	// FollowedBy requires the element to be immediately followed by one of these.
+	FollowedBy []string `json:"followed_by,omitempty"`
+	// NotFollowedBy requires the element to not be immediately followed by any of these.
+	NotFollowedBy []string `json:"not_followed_by,omitempty"`
+	// NotPrecededBy requires the element to not be immediately preceded by any of these with an omitted end tag.
+	NotPrecededBy []string `json:"not_preceded_by,omitempty"`
+	// LastInParent requires there to be no more content in the parent element.
+	LastInParent bool `json:"last_in_parent,omitempty"`
+	// ParentNot requires the parent element to be none of these.
+	ParentNot []string `json:"parent_not,omitempty"`
+}
+
+var (
+	omissionRule    = regexp.MustCompile("(start|end) tag (?:can|may) be omitted if (.+?)(?:\\.\\s|\\.$|$)")
+	omissionOr      = regexp.MustCompile(`,?\s+or\s+if\s+|,\s+if\s+`)
+	omissionAnd     = regexp.MustCompile(`,?\s+and\s+if\s+|\s+and\s+the\s+`)
+	omissionSubject = regexp.MustCompile("first thing inside the `[^`]+` element")
+	codeSpan        = regexp.MustCompile("`([^`]+)`")
+)
+
+// parseTagOmission parses the "Tag omission in text/html" entry of an element, given as text with code in backticks.
+func parseTagOmission(text string) *TagOmission {
+	out := &TagOmission{
+		NoEndTag: strings.Contains(text, "No end tag"),
+	}
+
+	for _, match := range omissionRule.FindAllStringSubmatch(text, -1) {
+		rule := &OmissionRule{
+			Text: strings.ReplaceAll(strings.TrimSpace(match[0]), "`", ""),
+		}
+
+		for _, clause := range omissionOr.Split(match[2], -1) {
+			if cond, ok := parseOmissionCondition(clause); ok {
+				rule.Conditions = append(rule.Conditions, cond)
+			}
+		}
+
+		if match[1] == "start" {
+			out.Start = rule
+		} else {
+			out.End = rule
+		}
+	}
+
+	if !out.NoEndTag && out.Start == nil && out.End == nil {
+		return nil
+	}
+
+	return out
+}
+
+func parseOmissionCondition(clause string) (OmissionCondition, bool) {
+	var cond OmissionCondition
+	found := false
+
+	for _, part := range omissionAnd.Split(clause, -1) {
+		part = omissionSubject.ReplaceAllString(part, "first thing inside")
+
+		switch {
+		case strings.Contains(part, "not immediately preceded by"):
+			cond.NotPrecededBy = codeSpans(after(part, "preceded by"))
+		case strings.Contains(part, "not immediately followed by"):
+			cond.NotFollowedBy = omissionNames(after(part, "followed by"))
+		case strings.Contains(part, "immediately followed by"):
+			cond.FollowedBy = omissionNames(after(part, "followed by"))
+		case strings.Contains(part, "no more content in the parent"):
+			cond.LastInParent = true
+		case strings.Contains(part, "that is not"):
+			cond.ParentNot = omissionNames(after(part, "that is not"))
+		case strings.Contains(part, "first thing inside is not"):
+			exclusions, exceptions, _ := strings.Cut(after(part, "is not"), "except")
+			cond.FirstChildNot = omissionNames(exclusions)
+			// Elements listed after the exception also prevent omission when they start the element's content, e.g. a
+			// script element as the first thing inside body.
+			cond.FirstChildNot = append(cond.FirstChildNot, codeSpans(exceptions)...)
+		case strings.Contains(part, "first thing inside is an element"):
+			cond.FirstChild = []string{OmissionElement}
+		case strings.Contains(part, "first thing inside is"):
+			cond.FirstChild = codeSpans(after(part, "first thing inside is"))
+		case strings.Contains(part, "is empty"):
+			cond.Empty = true
+		default:
+			continue
+		}
+
+		found = true
+	}
+
+	return cond, found
+}
+
+// omissionNames returns the element tags and special names mentioned in text.
+func omissionNames(text string) []string {
+	out := codeSpans(text)
+
+	if strings.Contains(text, "comment") {
+		out = append(out, OmissionComment)
+	}
+	if strings.Contains(text, "whitespace") {
+		out = append(out, OmissionWhitespace)
+	}
+	if strings.Contains(text, "autonomous custom element") {
+		out = append(out, OmissionCustomElement)
+	}
+
+	return out
+}
+
+func codeSpans(text string) []string {
+	var out []string
+	for _, match := range codeSpan.FindAllStringSubmatch(text, -1) {
+		out = append(out, match[1])
+	}
+
+	return out
+}
+
+func after(s, sep string) string {
+	if _, rest, ok := strings.Cut(s, sep); ok {
+		return rest
+	}
+
+	return ""
+}
+
+func (t *TagOmission) clone() *TagOmission {
+	if t == nil {
+		return nil
+	}
+
+	c := *t
+	c.Start = t.Start.clone()
+	c.End = t.End.clone()
+
+	return &c
+}
+
+func (r *OmissionRule) clone() *OmissionRule {
+	if r == nil {
+		return nil
+	}
+
+	c := *r
+	c.Conditions = make([]OmissionCondition, 0, len(r.Conditions))
+	for _, cond := range r.Conditions {
+		cond.FirstChild = slices.Clone(cond.FirstChild)
+		cond.FirstChildNot = slices.Clone(cond.FirstChildNot)
+		cond.FollowedBy = slices.Clone(cond.FollowedBy)
+		cond.NotFollowedBy = slices.Clone(cond.NotFollowedBy)
+		cond.NotPrecededBy = slices.Clone(cond.NotPrecededBy)
+		cond.ParentNot = slices.Clone(cond.ParentNot)
+		c.Conditions = append(c.Conditions, cond)
+	}
+
+	return &c
+}
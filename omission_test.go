@@ -0,0 +1,81 @@
+package spec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTagOmission(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want *TagOmission
+	}{
+		{
+			name: "neither",
+			text: "Neither tag is omissible.",
+			want: nil,
+		},
+		{
+			name: "void",
+			text: "No end tag.",
+			want: &TagOmission{NoEndTag: true},
+		},
+		{
+			name: "li",
+			text: "An `li` element's end tag can be omitted if the `li` element is immediately followed by another `li` element or if there is no more content in the parent element.",
+			want: &TagOmission{End: &OmissionRule{
+				Text: "end tag can be omitted if the li element is immediately followed by another li element or if there is no more content in the parent element.",
+				Conditions: []OmissionCondition{
+					{FollowedBy: []string{"li"}},
+					{LastInParent: true},
+				},
+			}},
+		},
+		{
+			name: "p",
+			text: "A `p` element's end tag can be omitted if the `p` element is immediately followed by an `address`, `article`, or `ul` element, or if there is no more content in the parent element and the parent element is an HTML element that is not an `a`, `audio`, or `video` element, or an autonomous custom element.",
+			want: &TagOmission{End: &OmissionRule{
+				Text: "end tag can be omitted if the p element is immediately followed by an address, article, or ul element, or if there is no more content in the parent element and the parent element is an HTML element that is not an a, audio, or video element, or an autonomous custom element.",
+				Conditions: []OmissionCondition{
+					{FollowedBy: []string{"address", "article", "ul"}},
+					{LastInParent: true, ParentNot: []string{"a", "audio", "video", OmissionCustomElement}},
+				},
+			}},
+		},
+		{
+			name: "body",
+			text: "A `body` element's start tag can be omitted if the element is empty, or if the first thing inside the `body` element is not ASCII whitespace or a comment, except if the first thing inside the `body` element is a `meta` or `template` element. A `body` element's end tag can be omitted if the `body` element is not immediately followed by a comment.",
+			want: &TagOmission{
+				Start: &OmissionRule{
+					Text: "start tag can be omitted if the element is empty, or if the first thing inside the body element is not ASCII whitespace or a comment, except if the first thing inside the body element is a meta or template element.",
+					Conditions: []OmissionCondition{
+						{Empty: true},
+						{FirstChildNot: []string{OmissionComment, OmissionWhitespace, "meta", "template"}},
+					},
+				},
+				End: &OmissionRule{
+					Text:       "end tag can be omitted if the body element is not immediately followed by a comment.",
+					Conditions: []OmissionCondition{{NotFollowedBy: []string{OmissionComment}}},
+				},
+			},
+		},
+		{
+			name: "colgroup",
+			text: "A `colgroup` element's start tag can be omitted if the first thing inside the `colgroup` element is a `col` element, and if the element is not immediately preceded by another `colgroup` element whose end tag has been omitted. (It can't be omitted if the element is empty.)",
+			want: &TagOmission{Start: &OmissionRule{
+				Text: "start tag can be omitted if the first thing inside the colgroup element is a col element, and if the element is not immediately preceded by another colgroup element whose end tag has been omitted.",
+				Conditions: []OmissionCondition{
+					{FirstChild: []string{"col"}, NotPrecededBy: []string{"colgroup"}},
+				},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseTagOmission(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTagOmission() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
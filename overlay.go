@@ -0,0 +1,251 @@
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Overlay describes changes to layer on top of a generated Spec, such as adding custom elements or tightening enums.
+type Overlay struct {
+	// Attributes patches the global attributes of the spec.
+	Attributes AttributePatch  `json:"attributes"`
+	Elements   []*ElementPatch `json:"elements,omitempty"`
+}
+
+// ElementPatch describes the changes to make to a single element.
+// When Add is set the element must not exist yet and is created, otherwise it must already exist.
+// Nil fields are left untouched.
+// Description is Markdown like Element.Description, the element's DescriptionText is set to it without markup.
+type ElementPatch struct {
+	Tag         string         `json:"tag"`
+	Add         bool           `json:"add,omitempty"`
+	Description *string        `json:"description,omitempty"`
+	Void        *bool          `json:"void,omitempty"`
+	Text        *bool          `json:"text,omitempty"`
+	Attributes  AttributePatch `json:"attributes"`
+
+	// CustomElement and Extends can only be set when adding an element.
+	CustomElement CustomElementKind `json:"custom_element,omitempty"`
+	Extends       string            `json:"extends,omitempty"`
+}
+
+// AttributePatch describes the changes to make to a list of attributes.
+// Removals are applied first, then replacements, additions and finally narrowing of enum values.
+type AttributePatch struct {
+	// Add holds attributes that must not exist yet.
+	Add []Attribute `json:"add,omitempty"`
+	// Remove holds the names of attributes that must exist.
+	Remove []string `json:"remove,omitempty"`
+	// Replace holds attributes that must exist and are swapped out by name.
+	Replace []Attribute `json:"replace,omitempty"`
+	// Narrow maps the names of AttributeTypeEnum attributes to the subset of Allowed values to keep.
+	// Narrowed enums no longer allow custom values.
+	Narrow map[string][]string `json:"narrow,omitempty"`
+}
+
+// UnmarshalJSON handles converting the marshaled json back into an AttributePatch struct.
+func (ap *AttributePatch) UnmarshalJSON(b []byte) error {
+	var tmp struct {
+		Add     []json.RawMessage   `json:"add,omitempty"`
+		Remove  []string            `json:"remove,omitempty"`
+		Replace []json.RawMessage   `json:"replace,omitempty"`
+		Narrow  map[string][]string `json:"narrow,omitempty"`
+	}
+
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	add, err := attrUnmarshal(tmp.Add)
+	if err != nil {
+		return err
+	}
+	replace, err := attrUnmarshal(tmp.Replace)
+	if err != nil {
+		return err
+	}
+
+	ap.Add = add
+	ap.Remove = tmp.Remove
+	ap.Replace = replace
+	ap.Narrow = tmp.Narrow
+
+	return nil
+}
+
+// LoadOverlay reads an Overlay from its json form.
+func LoadOverlay(r io.Reader) (*Overlay, error) {
+	var o Overlay
+	if err := json.NewDecoder(r).Decode(&o); err != nil {
+		return nil, fmt.Errorf("overlay: %w", err)
+	}
+
+	return &o, nil
+}
+
+// Apply layers the overlay on top of the spec.
+// Any conflict, such as adding an element or attribute that already exists, is returned as an error.
+// Apply is all or nothing: the patches are made on copies of the elements, which only replace the ones of the spec
+// once every patch succeeded, so the spec is left untouched when an error is returned.
+func (sp *Spec) Apply(overlay *Overlay) error {
+	attrs, err := overlay.Attributes.apply(sp.Attributes)
+	if err != nil {
+		return fmt.Errorf("overlay: global attributes: %w", err)
+	}
+
+	staged := &Spec{Elements: slices.Clone(sp.Elements)}
+	for _, patch := range overlay.Elements {
+		if err = staged.applyElementPatch(patch); err != nil {
+			return fmt.Errorf("overlay: element %q: %w", patch.Tag, err)
+		}
+	}
+
+	sp.Attributes = attrs
+	sp.Elements = staged.Elements
+
+	return nil
+}
+
+// applyElementPatch patches a copy of the element, which replaces the original in sp.Elements once it is valid.
+func (sp *Spec) applyElementPatch(patch *ElementPatch) error {
+	if patch.Tag == "" {
+		return errors.New("missing tag")
+	}
+
+	idx := slices.IndexFunc(sp.Elements, func(e *Element) bool {
+		return e.Tag == patch.Tag
+	})
+
+	var e Element
+	if patch.Add {
+		if idx != -1 {
+			return errors.New("element already exists")
+		}
+		e = Element{
+			Tag:           patch.Tag,
+			CustomElement: patch.CustomElement,
+			Extends:       patch.Extends,
+		}
+		if err := sp.validateElement(&e); err != nil {
+			return err
+		}
+		prepareElement(&e)
+	} else if idx == -1 {
+		return errors.New("element does not exist")
+	} else if patch.CustomElement != "" || patch.Extends != "" {
+		return errors.New("custom element kind can only be set when adding an element")
+	} else {
+		e = *sp.Elements[idx]
+	}
+
+	if patch.Description != nil {
+		e.Description = *patch.Description
+		e.DescriptionText = markdownText(*patch.Description)
+	}
+	if patch.Void != nil {
+		e.Void = *patch.Void
+	}
+	if patch.Text != nil {
+		e.Text = *patch.Text
+	}
+	if e.Void && e.Text {
+		return errors.New("element cannot be both void and allow text")
+	}
+
+	attrs, err := patch.Attributes.apply(e.Attributes)
+	if err != nil {
+		return err
+	}
+	e.Attributes = attrs
+
+	if idx == -1 {
+		sp.Elements = append(sp.Elements, &e)
+	} else {
+		sp.Elements[idx] = &e
+	}
+
+	return nil
+}
+
+func (ap *AttributePatch) apply(attrs []Attribute) ([]Attribute, error) {
+	// Work on a copy so the backing array of the original, which may be shared, is left alone.
+	attrs = slices.Clone(attrs)
+
+	for _, name := range ap.Remove {
+		idx := attributeIndex(attrs, name)
+		if idx == -1 {
+			return nil, fmt.Errorf("cannot remove attribute %q as it does not exist", name)
+		}
+		attrs = slices.Delete(attrs, idx, idx+1)
+	}
+
+	for _, attr := range ap.Replace {
+		idx := attributeIndex(attrs, attr.GetName())
+		if idx == -1 {
+			return nil, fmt.Errorf("cannot replace attribute %q as it does not exist", attr.GetName())
+		}
+		attrs[idx] = attr
+	}
+
+	for _, attr := range ap.Add {
+		if attributeIndex(attrs, attr.GetName()) != -1 {
+			return nil, fmt.Errorf("cannot add attribute %q as it already exists", attr.GetName())
+		}
+		attrs = append(attrs, attr)
+	}
+
+	// Sort the names so the first reported conflict does not depend on map ordering.
+	names := make([]string, 0, len(ap.Narrow))
+	for name := range ap.Narrow {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		idx := attributeIndex(attrs, name)
+		if idx == -1 {
+			return nil, fmt.Errorf("cannot narrow attribute %q as it does not exist", name)
+		}
+
+		narrowed, err := narrowEnum(attrs[idx], ap.Narrow[name])
+		if err != nil {
+			return nil, fmt.Errorf("cannot narrow attribute %q: %w", name, err)
+		}
+		attrs[idx] = narrowed
+	}
+
+	return attrs, nil
+}
+
+func narrowEnum(attr Attribute, values []string) (Attribute, error) {
+	enum, ok := attr.(*AttributeTypeEnum)
+	if !ok {
+		return nil, errors.New("only AttributeTypeEnum attributes can be narrowed")
+	}
+	if len(values) == 0 {
+		return nil, errors.New("no allowed values given")
+	}
+
+	var unknown []string
+	allowed := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		if _, ok = enum.Allowed[v]; !ok && !enum.AllowCustom {
+			unknown = append(unknown, v)
+		}
+		allowed[v] = struct{}{}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("values %s are not allowed", strings.Join(unknown, ", "))
+	}
+
+	// Clone as enums such as crossorigin are shared between elements.
+	narrowed := cloneAttribute(enum).(*AttributeTypeEnum)
+	narrowed.Allowed = allowed
+	narrowed.AllowCustom = false
+
+	return narrowed, nil
+}
@@ -0,0 +1,131 @@
+package spec
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSpec_Apply(t *testing.T) {
+	newSpec := func() *Spec {
+		return &Spec{
+			Name:       "HTML",
+			Attributes: []Attribute{&AttributeTypeString{Name: "id"}},
+			Elements: []*Element{
+				{Tag: "img", Void: true, Attributes: []Attribute{crossorigin, loading}},
+				{Tag: "div", Text: true},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		overlay string
+		wantErr string
+		check   func(t *testing.T, sp *Spec)
+	}{
+		{
+			name: "add custom element",
+			overlay: `{"elements": [{"tag": "x-tooltip", "add": true, "text": true, "attributes": {"add": [
+				{"name": "placement", "attribute_type": "AttributeTypeEnum", "allowed": {"top": {}, "bottom": {}}}
+			]}}]}`,
+			check: func(t *testing.T, sp *Spec) {
+				e, ok := sp.Element("x-tooltip")
+				if !ok {
+					t.Fatal("Apply() did not add x-tooltip")
+				}
+				if _, ok = e.Attribute("placement"); !ok || !e.Text {
+					t.Errorf("Apply() x-tooltip = %+v", e)
+				}
+			},
+		},
+		{
+			name:    "description",
+			overlay: `{"elements": [{"tag": "div", "description": "A *generic* [` + "`div`" + `](#the-div-element) container"}]}`,
+			check: func(t *testing.T, sp *Spec) {
+				e, _ := sp.Element("div")
+				if want := "A generic div container"; e.DescriptionText != want {
+					t.Errorf("Apply() DescriptionText = %q, want %q", e.DescriptionText, want)
+				}
+			},
+		},
+		{
+			name:    "narrow shared enum",
+			overlay: `{"elements": [{"tag": "img", "attributes": {"narrow": {"crossorigin": ["anonymous"]}}}]}`,
+			check: func(t *testing.T, sp *Spec) {
+				e, _ := sp.Element("img")
+				attr, _ := e.Attribute("crossorigin")
+				if got := len(attr.(*AttributeTypeEnum).Allowed); got != 1 {
+					t.Errorf("Apply() narrowed allowed = %d, want 1", got)
+				}
+				if got := len(crossorigin.Allowed); got != 2 {
+					t.Errorf("Apply() modified shared crossorigin, allowed = %d, want 2", got)
+				}
+			},
+		},
+		{
+			name:    "remove and replace",
+			overlay: `{"attributes": {"remove": ["id"]}, "elements": [{"tag": "img", "attributes": {"replace": [{"name": "loading", "attribute_type": "AttributeTypeString"}]}}]}`,
+			check: func(t *testing.T, sp *Spec) {
+				if len(sp.Attributes) != 0 {
+					t.Errorf("Apply() global attributes = %d, want 0", len(sp.Attributes))
+				}
+				e, _ := sp.Element("img")
+				if attr, _ := e.Attribute("loading"); attr == loading {
+					t.Error("Apply() did not replace loading")
+				}
+			},
+		},
+		{
+			name:    "add existing element",
+			overlay: `{"elements": [{"tag": "div", "add": true}]}`,
+			wantErr: "element already exists",
+		},
+		{
+			name:    "void with text",
+			overlay: `{"elements": [{"tag": "img", "text": true}]}`,
+			wantErr: "both void and allow text",
+		},
+		{
+			name:    "failed patch after successful ones",
+			overlay: `{"attributes": {"remove": ["id"]}, "elements": [{"tag": "div", "description": "changed"}, {"tag": "x-a", "add": true}, {"tag": "img", "description": "changed", "text": true}]}`,
+			wantErr: "both void and allow text",
+		},
+		{
+			name:    "narrow to unknown value",
+			overlay: `{"elements": [{"tag": "img", "attributes": {"narrow": {"loading": ["never"]}}}]}`,
+			wantErr: "values never are not allowed",
+		},
+		{
+			name:    "narrow non enum",
+			overlay: `{"attributes": {"narrow": {"id": ["a"]}}}`,
+			wantErr: "only AttributeTypeEnum",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlay, err := LoadOverlay(strings.NewReader(tt.overlay))
+			if err != nil {
+				t.Fatalf("LoadOverlay() error = %v", err)
+			}
+
+			sp := newSpec()
+			img, _ := sp.Element("img")
+			err = sp.Apply(overlay)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Apply() error = %v, want %q", err, tt.wantErr)
+				}
+				if !reflect.DeepEqual(sp, newSpec()) || img != sp.Elements[0] {
+					t.Errorf("Apply() changed the spec after an error: %+v", sp)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+
+			tt.check(t, sp)
+		})
+	}
+}
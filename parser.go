@@ -1,6 +1,8 @@
 package spec
 
 import (
+	"fmt"
+	"iter"
 	"slices"
 	"strings"
 
@@ -20,6 +22,8 @@ type Parser struct {
 	active      bool
 	currElement *Element
 	descParsed  bool
+	positions   *positions
+	warnings    []Warning
 	Spec        *Spec
 }
 
@@ -40,6 +44,42 @@ func (p *Parser) Activate(element string) {
 	}
 }
 
+// addParagraph adds the text of the paragraph node to the description of the current element.
+func (p *Parser) addParagraph(node *html.Node) {
+	text := gatherText(node)
+	if text == "" {
+		return
+	}
+
+	if p.currElement.Description != "" {
+		p.currElement.Description += "\n\n"
+		p.currElement.DescriptionText += "\n\n"
+	}
+	p.currElement.Description += gatherMarkdown(node)
+	p.currElement.DescriptionText += text
+	p.descParsed = true
+}
+
+// isIntroParagraph reports if node is a paragraph of running text, as opposed to paragraphs such as notes which have
+// a class.
+func isIntroParagraph(node *html.Node) bool {
+	if node.Type != html.ElementNode || node.Data != "p" {
+		return false
+	}
+
+	_, ok := getAttribute(node.Attr, "class")
+	return !ok
+}
+
+// warnf adds a warning at the position of node.
+func (p *Parser) warnf(node *html.Node, format string, args ...any) {
+	pos, _ := p.positions.of(node)
+	p.warnings = append(p.warnings, Warning{
+		Message:  fmt.Sprintf(format, args...),
+		Position: pos,
+	})
+}
+
 // Reset disables and resets the parsers state to begin parsing for new elements again.
 func (p *Parser) Reset() {
 	p.Spec.Elements = append(p.Spec.Elements, p.currElement)
@@ -90,21 +130,211 @@ func getAttribute(attrs []html.Attribute, key string) (string, bool) {
 	return "", false
 }
 
-func gatherText(node *html.Node, builder *strings.Builder) string {
-	if builder == nil {
-		builder = &strings.Builder{}
+// gatherText returns the text of node with its whitespace normalized, e.g. "The a element represents a hyperlink.".
+func gatherText(node *html.Node) string {
+	return strings.Join(strings.Fields(rawText(node)), " ")
+}
+
+// markdownEscaper escapes the characters of text nodes that would otherwise be read as Markdown.
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`)
+
+// gatherMarkdown returns the text of node as Markdown with its whitespace normalized.
+// Code elements become code spans, em and strong elements become emphasis, and links to the definitions of other
+// elements and attributes are kept, e.g. "The [`a`](#the-a-element) element represents a hyperlink.".
+// Any other markup is dropped.
+func gatherMarkdown(node *html.Node) string {
+	var walk func(n *html.Node) string
+	walk = func(n *html.Node) string {
+		if n.Type == html.TextNode {
+			return markdownEscaper.Replace(n.Data)
+		}
+
+		builder := &strings.Builder{}
+		for child := range n.ChildNodes() {
+			builder.WriteString(walk(child))
+		}
+		inner := builder.String()
+
+		if n.Type != html.ElementNode {
+			return inner
+		}
+
+		switch n.Data {
+		case "code":
+			code := strings.TrimSpace(rawText(n))
+			if code == "" {
+				return ""
+			}
+			span := "`" + code + "`"
+			if a, ok := findTag(n, "a"); ok {
+				if href, ok := crossReference(a); ok {
+					return "[" + span + "](" + href + ")"
+				}
+			}
+			return span
+		case "a":
+			if href, ok := crossReference(n); ok && strings.TrimSpace(inner) != "" {
+				return "[" + strings.TrimSpace(inner) + "](" + href + ")"
+			}
+		case "em", "i":
+			if text := strings.TrimSpace(inner); text != "" {
+				return "*" + text + "*"
+			}
+		case "strong", "b":
+			if text := strings.TrimSpace(inner); text != "" {
+				return "**" + text + "**"
+			}
+		}
+
+		return inner
+	}
+
+	return strings.Join(strings.Fields(walk(node)), " ")
+}
+
+// markdownText returns the text of the Markdown written by gatherMarkdown without its markup, e.g.
+// "The a element represents a hyperlink." for "The [`a`](#the-a-element) element represents a hyperlink.".
+func markdownText(md string) string {
+	builder := &strings.Builder{}
+
+	code := false
+	for i := 0; i < len(md); i++ {
+		c := md[i]
+		switch {
+		case code:
+			if c == '`' {
+				code = false
+			} else {
+				builder.WriteByte(c)
+			}
+		case c == '\\' && i+1 < len(md):
+			i++
+			builder.WriteByte(md[i])
+		case c == '`':
+			code = true
+		case c == '*', c == '[':
+		case c == ']' && strings.HasPrefix(md[i+1:], "("):
+			if end := strings.IndexByte(md[i:], ')'); end != -1 {
+				i += end
+			}
+		default:
+			builder.WriteByte(c)
+		}
+	}
+
+	return builder.String()
+}
+
+// crossReference returns the href of a link to the definition of an element or attribute, such as #the-a-element
+// or #attr-hyperlink-href.
+func crossReference(a *html.Node) (string, bool) {
+	href, ok := getAttribute(a.Attr, "href")
+	if !ok {
+		return "", false
+	}
+
+	fragment, ok := strings.CutPrefix(href, "#")
+	if !ok {
+		return "", false
+	}
+
+	if strings.HasPrefix(fragment, "attr-") ||
+		(strings.HasPrefix(fragment, "the-") && strings.Contains(fragment, "-element")) {
+		return href, true
+	}
+
+	return "", false
+}
+
+func hasClass(attrs []html.Attribute, class string) bool {
+	if val, ok := getAttribute(attrs, "class"); ok {
+		return slices.Contains(strings.Fields(val), class)
+	}
+
+	return false
+}
+
+// withDescendants yields node and then its descendants in depth-first pre-order, unlike node.Descendants which leaves
+// out node itself.
+func withDescendants(node *html.Node) iter.Seq[*html.Node] {
+	return func(yield func(*html.Node) bool) {
+		if !yield(node) {
+			return
+		}
+		for n := range node.Descendants() {
+			if !yield(n) {
+				return
+			}
+		}
 	}
+}
 
+// rawText returns the text content of node exactly as it appears in the document.
+func rawText(node *html.Node) string {
 	if node.Type == html.TextNode {
-		// TODO: We could probably do better here
-		cleaned := strings.ReplaceAll(node.Data, "\n   ", "")
-		cleaned = strings.ReplaceAll(cleaned, "\n ", "")
-		builder.WriteString(cleaned)
-	} else {
-		for child := range node.ChildNodes() {
-			gatherText(child, builder)
+		return node.Data
+	}
+
+	builder := &strings.Builder{}
+	for child := range node.Descendants() {
+		if child.Type == html.TextNode {
+			builder.WriteString(child.Data)
 		}
 	}
 
 	return builder.String()
 }
+
+// definitions groups the dd nodes of a dl by the text of the dt that precedes them, without the trailing colon.
+// Several dt nodes in a row share the dd nodes that follow them.
+func definitions(dl *html.Node) map[string][]*html.Node {
+	out := make(map[string][]*html.Node)
+
+	var terms []string
+	inTerms := false
+	for child := range dl.ChildNodes() {
+		if child.Type != html.ElementNode {
+			continue
+		}
+
+		switch child.Data {
+		case "dt":
+			if !inTerms {
+				terms = terms[:0]
+				inTerms = true
+			}
+			term := strings.TrimSuffix(strings.TrimSpace(strings.Join(strings.Fields(rawText(child)), " ")), ":")
+			terms = append(terms, term)
+		case "dd":
+			inTerms = false
+			for _, term := range terms {
+				out[term] = append(out[term], child)
+			}
+		}
+	}
+
+	return out
+}
+
+// gatherCodeText returns the whitespace normalized text of node with the content of code elements wrapped in backticks,
+// which keeps element and attribute names distinguishable from the prose around them.
+func gatherCodeText(node *html.Node) string {
+	builder := &strings.Builder{}
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			builder.WriteString(n.Data)
+		case n.Type == html.ElementNode && n.Data == "code":
+			builder.WriteString("`" + strings.TrimSpace(rawText(n)) + "`")
+		default:
+			for child := range n.ChildNodes() {
+				walk(child)
+			}
+		}
+	}
+	walk(node)
+
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
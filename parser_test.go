@@ -0,0 +1,126 @@
+package spec
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestGatherMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		fragment string
+		want     string
+		wantText string
+	}{
+		{
+			name:     "whitespace",
+			fragment: "The\n   element   represents\n a hyperlink.",
+			want:     "The element represents a hyperlink.",
+			wantText: "The element represents a hyperlink.",
+		},
+		{
+			name:     "element link",
+			fragment: `The <code><a href="#the-a-element">a</a></code> element`,
+			want:     "The [`a`](#the-a-element) element",
+			wantText: "The a element",
+		},
+		{
+			name:     "attribute link",
+			fragment: `its <a href="#attr-hyperlink-href"><code>href</code></a> attribute`,
+			want:     "its [`href`](#attr-hyperlink-href) attribute",
+			wantText: "its href attribute",
+		},
+		{
+			name:     "concept link",
+			fragment: `<a href="#represents">represents</a> <code>foo_bar</code>`,
+			want:     "represents `foo_bar`",
+			wantText: "represents foo_bar",
+		},
+		{
+			name:     "emphasis and escaping",
+			fragment: `<em>must</em> not use <strong>[HTML]</strong> *stars*`,
+			want:     `*must* not use **\[HTML\]** \*stars\*`,
+			wantText: "must not use [HTML] *stars*",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader("<p>" + tt.fragment + "</p>"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, _ := findTag(doc, "p")
+
+			if got := gatherMarkdown(p); got != tt.want {
+				t.Errorf("gatherMarkdown() = %q, want %q", got, tt.want)
+			}
+			if got := gatherText(p); got != tt.wantText {
+				t.Errorf("gatherText() = %q, want %q", got, tt.wantText)
+			}
+			if got := markdownText(tt.want); got != tt.wantText {
+				t.Errorf("markdownText() = %q, want %q", got, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestParseWithPositions(t *testing.T) {
+	src := "<!DOCTYPE html>\n<p id=a>x<br>\n<script>\"<p id=b>\"</script>\n<table><tr id=c><td>y</table>\n<p id=d>z"
+
+	doc, positions, err := parseWithPositions([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id   string
+		want Position
+	}{
+		{id: "a", want: Position{Offset: 16, Line: 2, Column: 1}},
+		{id: "c", want: Position{Offset: 65, Line: 4, Column: 8}},
+		{id: "d", want: Position{Offset: 88, Line: 5, Column: 1}},
+	}
+	for _, tt := range tests {
+		if got, ok := positions.id(tt.id); !ok || got != tt.want {
+			t.Errorf("positions.id(%q) = %v, %v, want %v", tt.id, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := positions.id("b"); ok {
+		t.Error(`positions.id("b") found an element in script text`)
+	}
+
+	// The implied tbody has no start tag, so its position is unknown rather than the one of its tr.
+	tbody, _ := findTag(doc, "tbody")
+	if got, ok := positions.of(tbody); ok {
+		t.Errorf("positions.of(tbody) = %v, want no position", got)
+	}
+}
+
+func TestParseWithPositions_Lines(t *testing.T) {
+	tests := []struct {
+		name       string
+		src        string
+		want       int
+		wantColumn int
+	}{
+		{name: "entity before newline", src: "<p title=\"a&amp;\nb\">\n<h4 id=x>", want: 3, wantColumn: 1},
+		{name: "newline reference", src: "<p title=\"a&#10;b\">\n<h4 id=x>", want: 2, wantColumn: 1},
+		{name: "quote entity", src: "<p title='&apos;\n&quot;'>\n\n<h4 id=x>", want: 4, wantColumn: 1},
+		{name: "column in characters", src: "<p title=\"&amp;\nü\">é <h4 id=x>", want: 2, wantColumn: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, positions, err := parseWithPositions([]byte(tt.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, _ := positions.id("x"); got.Line != tt.want || got.Column != tt.wantColumn {
+				t.Errorf("positions.id(\"x\") = %v, want line %d, column %d", got, tt.want, tt.wantColumn)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package spec
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// Position is a place in the document a spec is generated from.
+// Positions come from a second pass of the tokenizer over the document, whose start tags are matched to the parsed
+// element nodes in document order by name and attribute values, looking at most positionLookahead start tags
+// ahead. Elements the tree builder implies, such as a tbody without a start tag, or moves out of order, such as
+// foster parented content, have no position and are reported without one rather than with one of their neighbours.
+// The zero Position is used for an unknown position.
+type Position struct {
+	// Offset is the 0-based byte offset of the start tag of the node.
+	Offset int `json:"offset"`
+	// Line is the 1-based line of the start tag of the node.
+	Line int `json:"line"`
+	// Column is the 1-based column of the start tag of the node, counted in characters.
+	Column int `json:"column"`
+}
+
+func (pos Position) String() string {
+	return fmt.Sprintf("line %d, column %d", pos.Line, pos.Column)
+}
+
+// PositionError is an error found at a position of the document a spec is generated from.
+type PositionError struct {
+	Position Position
+	Err      error
+}
+
+func (e *PositionError) Error() string {
+	if e.Position.Line == 0 {
+		return e.Err.Error()
+	}
+
+	return e.Position.String() + ": " + e.Err.Error()
+}
+
+func (e *PositionError) Unwrap() error {
+	return e.Err
+}
+
+// positionLookahead is how many start tags past the expected one are tried when matching a node, which skips the
+// start tags that the tree builder drops, such as a second body.
+const positionLookahead = 16
+
+// impliedTags are the elements that the tree builder inserts without a start tag, e.g. tbody for a tr that is a
+// child of a table.
+var impliedTags = []string{"html", "head", "body", "tbody", "colgroup"}
+
+// startTag is a start tag read by the tokenizer.
+type startTag struct {
+	name string
+	attr []html.Attribute
+	pos  Position
+}
+
+// positions maps the element nodes of a parsed document to the positions of their start tags.
+type positions struct {
+	nodes map[*html.Node]Position
+	ids   map[string]Position
+}
+
+// parseWithPositions parses src like html.Parse and tokenizes it a second time to record the position of each
+// element node.
+// Start tags and element nodes are matched in document order by name and attribute values, so the elements that
+// the tree builder implies or moves have no position.
+func parseWithPositions(src []byte) (*html.Node, *positions, error) {
+	doc, err := html.Parse(bytes.NewReader(src))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tags []startTag
+	z := html.NewTokenizer(bytes.NewReader(src))
+	offset, line, lineStart := 0, 1, 0
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+
+		// Token unescapes attribute values in place, so the raw bytes are measured before it is called.
+		raw := z.Raw()
+		start := offset
+		offset += len(raw)
+		if tt == html.StartTagToken || tt == html.SelfClosingTagToken {
+			pos := Position{
+				Offset: start,
+				Line:   line,
+				Column: utf8.RuneCount(src[lineStart:start]) + 1,
+			}
+			tok := z.Token()
+			tags = append(tags, startTag{name: tok.Data, attr: tok.Attr, pos: pos})
+		}
+
+		// The column is counted from the last newline before the offset in src, which Token does not touch.
+		if last := bytes.LastIndexByte(src[start:offset], '\n'); last != -1 {
+			line += bytes.Count(src[start:offset], []byte("\n"))
+			lineStart = start + last + 1
+		}
+	}
+
+	out := &positions{
+		nodes: make(map[*html.Node]Position),
+		ids:   make(map[string]Position),
+	}
+
+	next := 0
+	for node := range doc.Descendants() {
+		if node.Type != html.ElementNode {
+			continue
+		}
+
+		end := min(next+positionLookahead, len(tags))
+		if len(node.Attr) == 0 && slices.Contains(impliedTags, node.Data) {
+			end = min(next+1, len(tags))
+		}
+
+		for i := next; i < end; i++ {
+			if !matchStartTag(node, tags[i]) {
+				continue
+			}
+
+			out.nodes[node] = tags[i].pos
+			if id, ok := getAttribute(node.Attr, "id"); ok {
+				if _, ok := out.ids[id]; !ok {
+					out.ids[id] = tags[i].pos
+				}
+			}
+			next = i + 1
+			break
+		}
+	}
+
+	return doc, out, nil
+}
+
+// matchStartTag reports if the element node was built from the start tag.
+// Attribute keys are not compared as the tree builder adjusts the ones of SVG and MathML elements.
+func matchStartTag(node *html.Node, tag startTag) bool {
+	if !strings.EqualFold(node.Data, tag.name) || len(node.Attr) != len(tag.attr) {
+		return false
+	}
+
+	for i, attr := range node.Attr {
+		if attr.Val != tag.attr[i].Val {
+			return false
+		}
+	}
+
+	return true
+}
+
+// of returns the position of the start tag that node was built from.
+// Nodes without one, such as text and the elements that the tree builder implies or moves, have no position.
+func (pos *positions) of(node *html.Node) (Position, bool) {
+	if pos == nil || node == nil {
+		return Position{}, false
+	}
+
+	p, ok := pos.nodes[node]
+	return p, ok
+}
+
+// id returns the position of the element with the given id.
+func (pos *positions) id(id string) (Position, bool) {
+	if pos == nil {
+		return Position{}, false
+	}
+
+	p, ok := pos.ids[id]
+	return p, ok
+}
@@ -0,0 +1,177 @@
+package spec
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// SanitizerOptions controls what a SanitizerPolicy keeps.
+type SanitizerOptions struct {
+	// URLSchemes lists the schemes that URL attributes may use, relative URLs are always kept.
+	// It defaults to http, https, mailto and tel.
+	URLSchemes []string
+	// AllowStyle keeps style attributes, which are otherwise removed as CSS can load resources and overlay content.
+	AllowStyle bool
+	// RemoveElements lists the elements that are removed together with their content.
+	// It defaults to the elements that run scripts, load other documents or change how the page is processed.
+	RemoveElements []string
+}
+
+// defaultRemoveElements is used when SanitizerOptions.RemoveElements is not set.
+var defaultRemoveElements = []string{
+	"base", "embed", "frame", "frameset", "iframe", "link", "meta", "noembed", "noframes", "noscript", "object",
+	"script", "style", "template",
+}
+
+// SanitizerPolicy is an allowlist of elements and attributes built from a Spec.
+// Elements and attributes that are not in the spec or are obsolete are removed, as are attributes whose security class makes
+// them dangerous for untrusted input, such as event handlers and URLs with a scheme like javascript:.
+type SanitizerPolicy struct {
+	spec     *Spec
+	elements map[string]*Element
+	remove   []string
+	schemes  []string
+	opts     SanitizerOptions
+}
+
+// NewSanitizerPolicy returns a SanitizerPolicy allowing the elements and attributes of sp.
+func NewSanitizerPolicy(sp *Spec, opts SanitizerOptions) *SanitizerPolicy {
+	p := &SanitizerPolicy{
+		spec:     sp,
+		elements: make(map[string]*Element, len(sp.Elements)),
+		remove:   opts.RemoveElements,
+		schemes:  opts.URLSchemes,
+		opts:     opts,
+	}
+
+	for _, e := range sp.Elements {
+		p.elements[e.Tag] = e
+	}
+	if p.remove == nil {
+		p.remove = defaultRemoveElements
+	}
+	if p.schemes == nil {
+		p.schemes = []string{"http", "https", "mailto", "tel"}
+	}
+
+	return p
+}
+
+// Sanitize removes everything from the children of n that the policy does not allow.
+// Elements that are not in the spec or are obsolete are replaced by their children, while elements in RemoveElements and
+// SVG and MathML content are removed entirely.
+func (p *SanitizerPolicy) Sanitize(n *html.Node) {
+	for child := n.FirstChild; child != nil; {
+		next := child.NextSibling
+
+		switch child.Type {
+		case html.ElementNode:
+			if child.Namespace != "" || slices.Contains(p.remove, child.Data) {
+				n.RemoveChild(child)
+				break
+			}
+
+			if e, ok := p.elements[child.Data]; !ok || e.Obsolete {
+				// The children are moved up so they are sanitized as part of n.
+				next = child.FirstChild
+				for grandchild := child.FirstChild; grandchild != nil; grandchild = child.FirstChild {
+					child.RemoveChild(grandchild)
+					n.InsertBefore(grandchild, child)
+				}
+				if next == nil {
+					next = child.NextSibling
+				}
+				n.RemoveChild(child)
+				break
+			}
+
+			child.Attr = slices.DeleteFunc(child.Attr, func(attr html.Attribute) bool {
+				return !p.AllowAttribute(child.Data, attr)
+			})
+			p.Sanitize(child)
+		case html.CommentNode:
+			n.RemoveChild(child)
+		default:
+			p.Sanitize(child)
+		}
+
+		child = next
+	}
+}
+
+// AllowAttribute reports if attr can be kept on the element with the given tag.
+func (p *SanitizerPolicy) AllowAttribute(tag string, attr html.Attribute) bool {
+	if attr.Namespace != "" {
+		return false
+	}
+
+	def, ok := p.spec.LookupAttribute(tag, attr.Key)
+	if !ok || def.Info().Obsolete {
+		return false
+	}
+
+	class := def.Info().Security
+	if class == "" {
+		// Specs generated before attributes were classified fall back to the well known attribute names.
+		class = securityClass(nil, tag, def)
+	}
+
+	switch class {
+	case SecurityScript, SecurityHTML:
+		return false
+	case SecurityStyle:
+		return p.opts.AllowStyle
+	case SecurityURL:
+		return p.allowURL(attr.Val)
+	case SecurityURLList:
+		for _, u := range urlListValues(attr.Key, attr.Val) {
+			if !p.allowURL(u) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// allowURL reports if u is relative or uses one of the allowed schemes.
+func (p *SanitizerPolicy) allowURL(u string) bool {
+	// URL parsing drops tabs and newlines anywhere in the URL, so java\tscript: is a javascript: URL.
+	u = strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(u))
+
+	end := strings.IndexAny(u, "/?#")
+	if end == -1 {
+		end = len(u)
+	}
+	scheme, _, found := strings.Cut(u[:end], ":")
+	if !found {
+		return true
+	}
+
+	return slices.Contains(p.schemes, strings.ToLower(scheme))
+}
+
+// urlListValues splits the value of a URL list attribute into its URLs.
+// srcset style values are comma separated image candidates, where the URL comes before any descriptor,
+// while the other lists are space separated.
+func urlListValues(name, value string) []string {
+	if !strings.HasSuffix(name, "srcset") {
+		return strings.Fields(value)
+	}
+
+	var out []string
+	for _, candidate := range strings.Split(value, ",") {
+		if fields := strings.Fields(candidate); len(fields) > 0 {
+			out = append(out, fields[0])
+		}
+	}
+
+	return out
+}
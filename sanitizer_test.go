@@ -0,0 +1,115 @@
+package spec
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestSanitizerPolicy_Sanitize(t *testing.T) {
+	sp := &Spec{
+		Name: "test",
+		Elements: []*Element{
+			{Tag: "a", Attributes: []Attribute{
+				&AttributeTypeString{Name: "href", AttributeInfo: AttributeInfo{Security: SecurityURL}},
+				&AttributeTypeString{Name: "ping", AttributeInfo: AttributeInfo{Security: SecurityURLList}},
+			}},
+			{Tag: "img", Attributes: []Attribute{
+				&AttributeTypeString{Name: "srcset", AttributeInfo: AttributeInfo{Security: SecurityURLList}},
+			}},
+			{Tag: "body", Attributes: []Attribute{
+				&AttributeTypeString{Name: "onload", AttributeInfo: AttributeInfo{Security: SecurityScript}},
+			}},
+			{Tag: "p"},
+			{Tag: "script"},
+		},
+		Attributes: []Attribute{
+			&AttributeTypeSST{Name: "class", AttributeInfo: AttributeInfo{Security: SecurityText}},
+			&AttributeTypeString{Name: "style", AttributeInfo: AttributeInfo{Security: SecurityStyle}},
+			// Without a class the attribute is classified by its name.
+			&AttributeTypeString{Name: "onclick"},
+			&AttributeTypePrefixedCustom{Name: "data"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts SanitizerOptions
+		in   string
+		want string
+	}{
+		{
+			name: "keeps allowed attributes",
+			in:   `<p class="x"><a href="/docs" ping="https://example.com/a https://example.com/b">docs</a></p>`,
+			want: `<p class="x"><a href="/docs" ping="https://example.com/a https://example.com/b">docs</a></p>`,
+		},
+		{
+			name: "drops javascript urls",
+			in:   `<a href=" JavaScript:alert(1)">a</a><a href="java&#9;script:alert(1)">b</a>`,
+			want: `<a>a</a><a>b</a>`,
+		},
+		{
+			name: "drops url lists with a bad url",
+			in:   `<img srcset="a.png 1x, javascript:alert(1) 2x"><a ping="/a data:text/html,x">a</a>`,
+			want: `<img/><a>a</a>`,
+		},
+		{
+			name: "drops scripts and unknown attributes",
+			in:   `<p onclick="alert(1)" data-x="javascript:1" foo="bar">text</p><script>alert(1)</script>`,
+			want: `<p data-x="javascript:1">text</p>`,
+		},
+		{
+			name: "unwraps unknown elements",
+			in:   `<x-card><p>one</p><blink>two</blink></x-card><!-- gone -->`,
+			want: `<p>one</p>two`,
+		},
+		{
+			name: "removes foreign content",
+			in:   `<p>a<svg><script>alert(1)</script></svg></p>`,
+			want: `<p>a</p>`,
+		},
+		{
+			name: "drops style by default",
+			in:   `<p style="color: red">a</p>`,
+			want: `<p>a</p>`,
+		},
+		{
+			name: "keeps style when allowed",
+			opts: SanitizerOptions{AllowStyle: true},
+			in:   `<p style="color: red">a</p>`,
+			want: `<p style="color: red">a</p>`,
+		},
+		{
+			name: "custom url schemes",
+			opts: SanitizerOptions{URLSchemes: []string{"https"}},
+			in:   `<a href="mailto:a@example.com">a</a><a href="https://example.com">b</a>`,
+			want: `<a>a</a><a href="https://example.com">b</a>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+			nodes, err := html.ParseFragment(strings.NewReader(tt.in), body)
+			if err != nil {
+				t.Fatalf("ParseFragment() error = %v", err)
+			}
+			for _, n := range nodes {
+				body.AppendChild(n)
+			}
+
+			NewSanitizerPolicy(sp, tt.opts).Sanitize(body)
+
+			var b strings.Builder
+			for n := range body.ChildNodes() {
+				if err = html.Render(&b, n); err != nil {
+					t.Fatalf("Render() error = %v", err)
+				}
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("Sanitize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
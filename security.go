@@ -0,0 +1,102 @@
+package spec
+
+import "strings"
+
+// SecurityClass describes what an attribute's value is interpreted as, which decides how it has to be escaped
+// or checked when the value comes from untrusted input.
+type SecurityClass string
+
+const (
+	// SecurityText values are plain text with no special meaning to the browser.
+	SecurityText SecurityClass = "text"
+	// SecurityURL values are a single URL that is navigated to or fetched, e.g. href and src.
+	SecurityURL SecurityClass = "url"
+	// SecurityURLList values hold several URLs, such as the image candidates of srcset or the URLs of ping.
+	SecurityURLList SecurityClass = "url-list"
+	// SecurityScript values are run as JavaScript, e.g. event handler content attributes such as onload.
+	SecurityScript SecurityClass = "script"
+	// SecurityStyle values are CSS declarations, i.e. the style attribute.
+	SecurityStyle SecurityClass = "style"
+	// SecurityCSS values use other CSS syntax, such as the media queries of media and the source sizes of sizes.
+	SecurityCSS SecurityClass = "css"
+	// SecurityHTML values are parsed as markup, i.e. the srcdoc attribute of iframe.
+	SecurityHTML SecurityClass = "html"
+)
+
+// securityDefaults classifies well known attributes when the spec's attribute index could not be read.
+var securityDefaults = map[string]SecurityClass{
+	"action":      SecurityURL,
+	"background":  SecurityURL,
+	"classid":     SecurityURL,
+	"codebase":    SecurityURL,
+	"longdesc":    SecurityURL,
+	"profile":     SecurityURL,
+	"cite":        SecurityURL,
+	"data":        SecurityURL,
+	"formaction":  SecurityURL,
+	"href":        SecurityURL,
+	"imagesizes":  SecurityCSS,
+	"imagesrcset": SecurityURLList,
+	"itemid":      SecurityURL,
+	"itemtype":    SecurityURLList,
+	"manifest":    SecurityURL,
+	"media":       SecurityCSS,
+	"ping":        SecurityURLList,
+	"poster":      SecurityURL,
+	"sizes":       SecurityCSS,
+	"src":         SecurityURL,
+	"srcdoc":      SecurityHTML,
+	"srcset":      SecurityURLList,
+	"style":       SecurityStyle,
+}
+
+// DefaultSecurityClass returns the class of an attribute whose value column is not known,
+// based on its name alone. Names starting with "on" are treated as event handlers.
+func DefaultSecurityClass(name string) SecurityClass {
+	if class, ok := securityDefaults[name]; ok {
+		return class
+	}
+	if strings.HasPrefix(name, "on") && len(name) > 2 {
+		return SecurityScript
+	}
+
+	return SecurityText
+}
+
+// classifyValue returns the class of an attribute from the text of its "Value" column in the attribute index.
+func classifyValue(value string) SecurityClass {
+	value = strings.ToLower(value)
+
+	switch {
+	case strings.Contains(value, "image candidate"):
+		return SecurityURLList
+	case strings.Contains(value, "url") && (strings.Contains(value, "tokens") || strings.Contains(value, "list")):
+		return SecurityURLList
+	case strings.Contains(value, "url"):
+		return SecurityURL
+	case strings.Contains(value, "css declarations"):
+		return SecurityStyle
+	case strings.Contains(value, "media query"), strings.Contains(value, "source size"):
+		return SecurityCSS
+	case strings.Contains(value, "srcdoc"):
+		return SecurityHTML
+	case strings.Contains(value, "event handler"):
+		return SecurityScript
+	}
+
+	return SecurityText
+}
+
+// securityClass returns the class of attr on the element with the given tag.
+// Prefixed custom attributes such as data-* and aria-* only ever hold text, even though data shares its name
+// with the URL attribute of object.
+func securityClass(idx valueIndex, tag string, attr Attribute) SecurityClass {
+	if _, ok := attr.(*AttributeTypePrefixedCustom); ok {
+		return SecurityText
+	}
+	if value, ok := idx.value(tag, attr.GetName()); ok {
+		return classifyValue(value)
+	}
+
+	return DefaultSecurityClass(attr.GetName())
+}
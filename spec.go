@@ -2,6 +2,42 @@ package spec
 
 import (
 	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// The kinds of content that elements are grouped into by the spec.
+const (
+	CategoryMetadata         = "Metadata content"
+	CategoryFlow             = "Flow content"
+	CategorySectioning       = "Sectioning content"
+	CategoryHeading          = "Heading content"
+	CategoryPhrasing         = "Phrasing content"
+	CategoryEmbedded         = "Embedded content"
+	CategoryInteractive      = "Interactive content"
+	CategoryPalpable         = "Palpable content"
+	CategoryScriptSupporting = "Script-supporting element"
+)
+
+// ElementKind is one of the six kinds of elements that the HTML syntax distinguishes between.
+// The kind decides how an element's content is parsed and so how it has to be escaped when rendered.
+type ElementKind string
+
+const (
+	// ElementKindVoid elements have no content and no end tag, e.g. br.
+	ElementKindVoid ElementKind = "void"
+	// ElementKindTemplate is the template element, whose content is a separate document fragment.
+	ElementKindTemplate ElementKind = "template"
+	// ElementKindRawText elements hold text that is never escaped and cannot contain their own end tag, e.g. script.
+	ElementKindRawText ElementKind = "raw-text"
+	// ElementKindEscapableRawText elements hold text that may contain character references, e.g. textarea.
+	ElementKindEscapableRawText ElementKind = "escapable-raw-text"
+	// ElementKindForeign elements come from the MathML and SVG namespaces.
+	ElementKindForeign ElementKind = "foreign"
+	// ElementKindNormal covers every other element.
+	ElementKindNormal ElementKind = "normal"
 )
 
 // Spec defines the spec document that all found elements and their attributes are parsed into.
@@ -9,6 +45,7 @@ type Spec struct {
 	Name       string      `json:"name"`
 	Elements   []*Element  `json:"elements"`
 	Attributes []Attribute `json:"attributes,omitempty"`
+	LinkTypes  []*LinkType `json:"link_types,omitempty"`
 }
 
 func attrUnmarshal(in []json.RawMessage) ([]Attribute, error) {
@@ -32,7 +69,7 @@ func attrUnmarshal(in []json.RawMessage) ([]Attribute, error) {
 				return nil, err
 			}
 			out = append(out, a)
-		case "AttributeType":
+		case "AttributeTypeChar":
 			a := &AttributeTypeChar{}
 			if err := json.Unmarshal(attr, &a); err != nil {
 				return nil, err
@@ -74,6 +111,56 @@ func attrUnmarshal(in []json.RawMessage) ([]Attribute, error) {
 				return nil, err
 			}
 			out = append(out, a)
+		case "AttributeTypeURL":
+			a := &AttributeTypeURL{}
+			if err := json.Unmarshal(attr, &a); err != nil {
+				return nil, err
+			}
+			out = append(out, a)
+		case "AttributeTypeURLList":
+			a := &AttributeTypeURLList{}
+			if err := json.Unmarshal(attr, &a); err != nil {
+				return nil, err
+			}
+			out = append(out, a)
+		case "AttributeTypeDateTime":
+			a := &AttributeTypeDateTime{}
+			if err := json.Unmarshal(attr, &a); err != nil {
+				return nil, err
+			}
+			out = append(out, a)
+		case "AttributeTypeMIME":
+			a := &AttributeTypeMIME{}
+			if err := json.Unmarshal(attr, &a); err != nil {
+				return nil, err
+			}
+			out = append(out, a)
+		case "AttributeTypeMediaQuery":
+			a := &AttributeTypeMediaQuery{}
+			if err := json.Unmarshal(attr, &a); err != nil {
+				return nil, err
+			}
+			out = append(out, a)
+		case "AttributeTypeColor":
+			a := &AttributeTypeColor{}
+			if err := json.Unmarshal(attr, &a); err != nil {
+				return nil, err
+			}
+			out = append(out, a)
+		case "AttributeTypeSrcset":
+			a := &AttributeTypeSrcset{}
+			if err := json.Unmarshal(attr, &a); err != nil {
+				return nil, err
+			}
+			out = append(out, a)
+		case "AttributeTypeSizes":
+			a := &AttributeTypeSizes{}
+			if err := json.Unmarshal(attr, &a); err != nil {
+				return nil, err
+			}
+			out = append(out, a)
+		default:
+			return nil, fmt.Errorf("attribute %q has unknown attribute type %q", tmpAttr.Name, tmpAttr.AttributeType)
 		}
 	}
 
@@ -86,6 +173,7 @@ func (sp *Spec) UnmarshalJSON(b []byte) error {
 		Name       string            `json:"name"`
 		Elements   []*Element        `json:"elements"`
 		Attributes []json.RawMessage `json:"attributes,omitempty"`
+		LinkTypes  []*LinkType       `json:"link_types,omitempty"`
 	}
 
 	if err := json.Unmarshal(b, &tmp); err != nil {
@@ -94,6 +182,7 @@ func (sp *Spec) UnmarshalJSON(b []byte) error {
 
 	sp.Name = tmp.Name
 	sp.Elements = tmp.Elements
+	sp.LinkTypes = tmp.LinkTypes
 	attrs, err := attrUnmarshal(tmp.Attributes)
 	if err != nil {
 		return err
@@ -103,26 +192,144 @@ func (sp *Spec) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// Element returns the element with the given tag.
+func (sp *Spec) Element(tag string) (*Element, bool) {
+	for _, e := range sp.Elements {
+		if e.Tag == tag {
+			return e, true
+		}
+	}
+
+	return nil, false
+}
+
+// AddElements adds the given elements to the spec, returning an error if any of their tags already exist
+// or a custom element is invalid.
+// No elements are added if an error is returned.
+func (sp *Spec) AddElements(elements ...*Element) error {
+	seen := make(map[string]struct{}, len(elements))
+	for _, e := range elements {
+		if _, ok := sp.Element(e.Tag); ok {
+			return fmt.Errorf("element %q already exists", e.Tag)
+		}
+		if _, ok := seen[e.Tag]; ok {
+			return fmt.Errorf("element %q is given more than once", e.Tag)
+		}
+		if err := sp.validateElement(e); err != nil {
+			return err
+		}
+		seen[e.Tag] = struct{}{}
+	}
+
+	for _, e := range elements {
+		prepareElement(e)
+	}
+
+	sp.Elements = append(sp.Elements, elements...)
+
+	return nil
+}
+
 // Element represents an element ia specifications such as HTML or SVG.
 // An element has attributes that are relative only to itself but also inherits any global attributes defined by the spec.
 type Element struct {
-	Tag         string      `json:"tag"`
-	Description string      `json:"description,omitempty"`
-	Attributes  []Attribute `json:"attributes,omitempty"`
+	Tag string `json:"tag"`
+
+	// Description is the element's introductory text from the spec in Markdown, keeping code spans and links to
+	// other elements and attributes. DescriptionText is the same text without any markup.
+	// Paragraphs are separated by a blank line in both.
+	Description     string `json:"description,omitempty"`
+	DescriptionText string `json:"description_text,omitempty"`
+
+	Attributes []Attribute `json:"attributes,omitempty"`
+
+	// URL links to the element's definition in the spec, e.g. https://html.spec.whatwg.org/#the-a-element.
+	// Generated specs hold links relative to the spec's document until Spec.ResolveURLs is called.
+	URL string `json:"url,omitempty"`
 
 	// A Void element has no children
 	Void bool `json:"void,omitempty"`
 	Text bool `json:"text,omitempty"`
+
+	// Kind is the kind of element as far as the HTML syntax is concerned.
+	Kind ElementKind `json:"kind,omitempty"`
+
+	// Categories holds the content categories the element belongs to as written in the spec, e.g. "Phrasing content".
+	// Some categories only apply under a condition, e.g. "If the element has an href attribute: Interactive content".
+	Categories []string `json:"categories,omitempty"`
+
+	// Contexts are the places the element can be used in, any one of which is enough, e.g. inside ol, ul or menu for li.
+	Contexts []Context `json:"contexts,omitempty"`
+
+	// TagOmission is nil when neither of the element's tags can be omitted.
+	TagOmission *TagOmission `json:"tag_omission,omitempty"`
+
+	// Interface is the name of the element's DOM interface, e.g. HTMLAnchorElement.
+	// Reflections maps content attribute names to the IDL attributes of the interface that reflect them.
+	Interface   string                `json:"interface,omitempty"`
+	Reflections map[string]Reflection `json:"reflections,omitempty"`
+
+	// CustomElement is set for elements that are not built into HTML, in which case Tag holds the custom element name.
+	// Extends holds the tag of the built-in element that a customized built-in element is used with.
+	// A customized built-in element has no tag of its own, so it is written as its Extends element with Tag as the
+	// value of the is attribute, e.g. <button is="x-button"> rather than <x-button>.
+	CustomElement CustomElementKind `json:"custom_element,omitempty"`
+	Extends       string            `json:"extends,omitempty"`
+
+	// Slots and Events are only known for custom elements imported from a manifest.
+	Slots  []Slot  `json:"slots,omitempty"`
+	Events []Event `json:"events,omitempty"`
+
+	// Obsolete is set for elements from the spec's obsolete features chapter, which must not be used by authors.
+	// Replacement holds the spec's suggestion of what to use instead, e.g. "Use CSS instead.".
+	Obsolete    bool   `json:"obsolete,omitempty"`
+	Replacement string `json:"replacement,omitempty"`
+
+	// Compat is the element's browser support, see Spec.ApplyCompat.
+	// Stability is set for elements that the spec flags as not being supported by most browsers yet.
+	Compat    *Compat   `json:"compat,omitempty"`
+	Stability Stability `json:"stability,omitempty"`
+}
+
+// Slot describes a slot that content can be assigned to in an element's shadow tree.
+// The default slot has an empty Name.
+type Slot struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Event describes an event that is dispatched by an element.
+type Event struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Type is the type of the dispatched event, e.g. CustomEvent.
+	Type string `json:"type,omitempty"`
 }
 
 // UnmarshalJSON handles converting the marshaled json back into an Element struct.
 func (e *Element) UnmarshalJSON(b []byte) error {
 	var tmp struct {
-		Tag         string            `json:"tag"`
-		Description string            `json:"description,omitempty"`
-		Attributes  []json.RawMessage `json:"attributes,omitempty"`
-		Void        bool              `json:"void,omitempty"`
-		Text        bool              `json:"text,omitempty"`
+		Tag             string                `json:"tag"`
+		Description     string                `json:"description,omitempty"`
+		DescriptionText string                `json:"description_text,omitempty"`
+		Attributes      []json.RawMessage     `json:"attributes,omitempty"`
+		URL             string                `json:"url,omitempty"`
+		Void            bool                  `json:"void,omitempty"`
+		Text            bool                  `json:"text,omitempty"`
+		Kind            ElementKind           `json:"kind,omitempty"`
+		Categories      []string              `json:"categories,omitempty"`
+		Contexts        []Context             `json:"contexts,omitempty"`
+		TagOmission     *TagOmission          `json:"tag_omission,omitempty"`
+		Interface       string                `json:"interface,omitempty"`
+		Reflections     map[string]Reflection `json:"reflections,omitempty"`
+		CustomElement   CustomElementKind     `json:"custom_element,omitempty"`
+		Extends         string                `json:"extends,omitempty"`
+		Slots           []Slot                `json:"slots,omitempty"`
+		Events          []Event               `json:"events,omitempty"`
+		Obsolete        bool                  `json:"obsolete,omitempty"`
+		Replacement     string                `json:"replacement,omitempty"`
+		Compat          *Compat               `json:"compat,omitempty"`
+		Stability       Stability             `json:"stability,omitempty"`
 	}
 
 	if err := json.Unmarshal(b, &tmp); err != nil {
@@ -131,8 +338,24 @@ func (e *Element) UnmarshalJSON(b []byte) error {
 
 	e.Tag = tmp.Tag
 	e.Description = tmp.Description
+	e.DescriptionText = tmp.DescriptionText
+	e.URL = tmp.URL
 	e.Void = tmp.Void
 	e.Text = tmp.Text
+	e.Kind = tmp.Kind
+	e.Categories = tmp.Categories
+	e.Contexts = tmp.Contexts
+	e.TagOmission = tmp.TagOmission
+	e.Interface = tmp.Interface
+	e.Reflections = tmp.Reflections
+	e.CustomElement = tmp.CustomElement
+	e.Extends = tmp.Extends
+	e.Slots = tmp.Slots
+	e.Events = tmp.Events
+	e.Obsolete = tmp.Obsolete
+	e.Replacement = tmp.Replacement
+	e.Compat = tmp.Compat
+	e.Stability = tmp.Stability
 	attrs, err := attrUnmarshal(tmp.Attributes)
 	if err != nil {
 		return err
@@ -142,16 +365,192 @@ func (e *Element) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// HasCategory reports if the element unconditionally belongs to the given content category, e.g. CategoryPhrasing.
+func (e *Element) HasCategory(category string) bool {
+	return slices.ContainsFunc(e.Categories, func(c string) bool {
+		return strings.EqualFold(c, category)
+	})
+}
+
+// Attribute returns the element specific attribute with the given name.
+// Global attributes are not included, see Spec.Attributes for those.
+func (e *Element) Attribute(name string) (Attribute, bool) {
+	if idx := attributeIndex(e.Attributes, name); idx != -1 {
+		return e.Attributes[idx], true
+	}
+
+	return nil, false
+}
+
+// Filter removes the elements and attributes that the given functions do not keep.
+// Attributes are filtered on the remaining elements as well as on the spec's global attributes.
+// A nil function keeps everything.
+func (sp *Spec) Filter(keepElement func(e *Element) bool, keepAttribute func(attr Attribute) bool) {
+	if keepElement != nil {
+		sp.Elements = slices.DeleteFunc(sp.Elements, func(e *Element) bool {
+			return !keepElement(e)
+		})
+	}
+
+	if keepAttribute != nil {
+		drop := func(attr Attribute) bool {
+			return !keepAttribute(attr)
+		}
+
+		sp.Attributes = slices.DeleteFunc(sp.Attributes, drop)
+		for _, e := range sp.Elements {
+			e.Attributes = slices.DeleteFunc(e.Attributes, drop)
+		}
+	}
+}
+
+// LookupAttribute returns the attribute with the given name that applies to the element with the given tag.
+// Element specific attributes take precedence over global ones, and names such as data-foo match the
+// AttributeTypePrefixedCustom attribute for their prefix.
+func (sp *Spec) LookupAttribute(tag, name string) (Attribute, bool) {
+	if e, ok := sp.Element(tag); ok {
+		if attr, ok := e.Attribute(name); ok {
+			return attr, true
+		}
+	}
+
+	if idx := attributeIndex(sp.Attributes, name); idx != -1 {
+		return sp.Attributes[idx], true
+	}
+
+	for _, attr := range sp.Attributes {
+		if _, ok := attr.(*AttributeTypePrefixedCustom); ok && len(name) > len(attr.GetName())+1 && strings.HasPrefix(name, attr.GetName()+"-") {
+			return attr, true
+		}
+	}
+
+	return nil, false
+}
+
+func attributeIndex(attrs []Attribute, name string) int {
+	for i, attr := range attrs {
+		if attr.GetName() == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// cloneAttribute returns a copy of attr that can be modified without affecting attributes shared between elements.
+func cloneAttribute(attr Attribute) Attribute {
+	switch a := attr.(type) {
+	case *AttributeTypeString:
+		c := *a
+		return &c
+	case *AttributeTypeChar:
+		c := *a
+		return &c
+	case *AttributeTypeNumber:
+		c := *a
+		c.Min = clonePointer(a.Min)
+		c.Max = clonePointer(a.Max)
+		return &c
+	case *AttributeTypeFloat:
+		c := *a
+		c.Min = clonePointer(a.Min)
+		c.Max = clonePointer(a.Max)
+		return &c
+	case *AttributeTypeBool:
+		c := *a
+		return &c
+	case *AttributeTypeEnum:
+		c := *a
+		c.Allowed = maps.Clone(a.Allowed)
+		return &c
+	case *AttributeTypeSST:
+		c := *a
+		c.Allowed = maps.Clone(a.Allowed)
+		c.Prefixes = slices.Clone(a.Prefixes)
+		c.Autofill = a.Autofill.clone()
+		return &c
+	case *AttributeTypePrefixedCustom:
+		c := *a
+		return &c
+	case *AttributeTypeURL:
+		c := *a
+		return &c
+	case *AttributeTypeURLList:
+		c := *a
+		return &c
+	case *AttributeTypeDateTime:
+		c := *a
+		c.Syntaxes = slices.Clone(a.Syntaxes)
+		return &c
+	case *AttributeTypeMIME:
+		c := *a
+		c.Keywords = slices.Clone(a.Keywords)
+		return &c
+	case *AttributeTypeMediaQuery:
+		c := *a
+		return &c
+	case *AttributeTypeColor:
+		c := *a
+		return &c
+	case *AttributeTypeSrcset:
+		c := *a
+		return &c
+	case *AttributeTypeSizes:
+		c := *a
+		return &c
+	}
+
+	return attr
+}
+
+func pointer[T any](v T) *T {
+	return &v
+}
+
+func clonePointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+
+	c := *p
+	return &c
+}
+
 // Attribute defines the interface that all attributes must conform to.
 type Attribute interface {
 	isAttr()
 	GetName() string
+	Info() *AttributeInfo
+}
+
+// AttributeInfo holds the details that apply to attributes whatever their type.
+// It is embedded in every attribute type so its fields are part of the attribute's JSON.
+type AttributeInfo struct {
+	// Security classifies the attribute's value by how it has to be treated when it holds untrusted input.
+	Security SecurityClass `json:"security,omitempty"`
+	// Obsolete is set for attributes from the spec's obsolete features chapter, which must not be used by authors.
+	// Replacement holds the spec's suggestion of what to use instead.
+	Obsolete    bool   `json:"obsolete,omitempty"`
+	Replacement string `json:"replacement,omitempty"`
+	// URL links to the attribute's definition in the spec, see Spec.ResolveURLs.
+	URL string `json:"url,omitempty"`
+	// Compat is the attribute's browser support, see Spec.ApplyCompat.
+	Compat *Compat `json:"compat,omitempty"`
+	// Stability is set for attributes that the spec flags as not being supported by most browsers yet.
+	Stability Stability `json:"stability,omitempty"`
+}
+
+// Info returns the details shared by all attribute types so they can be read and set without a type switch.
+func (i *AttributeInfo) Info() *AttributeInfo {
+	return i
 }
 
 // AttributeTypeString allows for setting string values on an attribute.
 type AttributeTypeString struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
+
+	AttributeInfo
 }
 
 func (a AttributeTypeString) isAttr() {}
@@ -165,10 +564,13 @@ func (a AttributeTypeString) MarshalJSON() ([]byte, error) {
 		Name          string `json:"name"`
 		Description   string `json:"description,omitempty"`
 		AttributeType string `json:"attribute_type"`
+
+		AttributeInfo
 	}{
 		Name:          a.Name,
 		Description:   a.Description,
 		AttributeType: "AttributeTypeString",
+		AttributeInfo: a.AttributeInfo,
 	})
 }
 
@@ -176,6 +578,8 @@ func (a AttributeTypeString) MarshalJSON() ([]byte, error) {
 type AttributeTypeChar struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
+
+	AttributeInfo
 }
 
 func (a AttributeTypeChar) isAttr() {}
@@ -189,17 +593,29 @@ func (a AttributeTypeChar) MarshalJSON() ([]byte, error) {
 		Name          string `json:"name"`
 		Description   string `json:"description,omitempty"`
 		AttributeType string `json:"attribute_type"`
+
+		AttributeInfo
 	}{
 		Name:          a.Name,
 		Description:   a.Description,
 		AttributeType: "AttributeTypeChar",
+		AttributeInfo: a.AttributeInfo,
 	})
 }
 
 // AttributeTypeNumber allows for setting integer values on an attribute.
+// Min and Max fields hold the inclusive bounds of the value when the spec limits it.
+// NonNegative field accounts for values that cannot be below zero and Positive field for values that must be
+// above zero.
 type AttributeTypeNumber struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	Min         *int   `json:"min,omitempty"`
+	Max         *int   `json:"max,omitempty"`
+	NonNegative bool   `json:"non_negative,omitempty"`
+	Positive    bool   `json:"positive,omitempty"`
+
+	AttributeInfo
 }
 
 func (a AttributeTypeNumber) isAttr() {}
@@ -212,18 +628,38 @@ func (a AttributeTypeNumber) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
 		Name          string `json:"name"`
 		Description   string `json:"description,omitempty"`
+		Min           *int   `json:"min,omitempty"`
+		Max           *int   `json:"max,omitempty"`
+		NonNegative   bool   `json:"non_negative,omitempty"`
+		Positive      bool   `json:"positive,omitempty"`
 		AttributeType string `json:"attribute_type"`
+
+		AttributeInfo
 	}{
 		Name:          a.Name,
 		Description:   a.Description,
+		Min:           a.Min,
+		Max:           a.Max,
+		NonNegative:   a.NonNegative,
+		Positive:      a.Positive,
 		AttributeType: "AttributeTypeNumber",
+		AttributeInfo: a.AttributeInfo,
 	})
 }
 
 // AttributeTypeFloat allows for setting float values on an attribute.
+// Min and Max fields hold the inclusive bounds of the value when the spec limits it.
+// NonNegative field accounts for values that cannot be below zero and Positive field for values that must be
+// above zero.
 type AttributeTypeFloat struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Min         *float64 `json:"min,omitempty"`
+	Max         *float64 `json:"max,omitempty"`
+	NonNegative bool     `json:"non_negative,omitempty"`
+	Positive    bool     `json:"positive,omitempty"`
+
+	AttributeInfo
 }
 
 func (a AttributeTypeFloat) isAttr() {}
@@ -234,13 +670,24 @@ func (a AttributeTypeFloat) GetName() string {
 
 func (a AttributeTypeFloat) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
-		Name          string `json:"name"`
-		Description   string `json:"description,omitempty"`
-		AttributeType string `json:"attribute_type"`
+		Name          string   `json:"name"`
+		Description   string   `json:"description,omitempty"`
+		Min           *float64 `json:"min,omitempty"`
+		Max           *float64 `json:"max,omitempty"`
+		NonNegative   bool     `json:"non_negative,omitempty"`
+		Positive      bool     `json:"positive,omitempty"`
+		AttributeType string   `json:"attribute_type"`
+
+		AttributeInfo
 	}{
 		Name:          a.Name,
 		Description:   a.Description,
+		Min:           a.Min,
+		Max:           a.Max,
+		NonNegative:   a.NonNegative,
+		Positive:      a.Positive,
 		AttributeType: "AttributeTypeFloat",
+		AttributeInfo: a.AttributeInfo,
 	})
 }
 
@@ -248,6 +695,8 @@ func (a AttributeTypeFloat) MarshalJSON() ([]byte, error) {
 type AttributeTypeBool struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
+
+	AttributeInfo
 }
 
 func (a AttributeTypeBool) isAttr() {}
@@ -261,10 +710,13 @@ func (a AttributeTypeBool) MarshalJSON() ([]byte, error) {
 		Name          string `json:"name"`
 		Description   string `json:"description,omitempty"`
 		AttributeType string `json:"attribute_type"`
+
+		AttributeInfo
 	}{
 		Name:          a.Name,
 		Description:   a.Description,
 		AttributeType: "AttributeTypeBool",
+		AttributeInfo: a.AttributeInfo,
 	})
 }
 
@@ -272,12 +724,16 @@ func (a AttributeTypeBool) MarshalJSON() ([]byte, error) {
 // AllowEmpty field accounts for if spec allows for attributes to be empty.
 // AllowCustom field accounts for if the spec allows for a set of specific enums but may also allow custom values.
 // Allow field should contain a list of allowed enum values as defined by the spec.
+// MissingDefault field holds the keyword whose state applies when the attribute is not set, if there is one.
 type AttributeTypeEnum struct {
-	Name        string              `json:"name"`
-	Description string              `json:"description"`
-	Allowed     map[string]struct{} `json:"allowed"`
-	AllowCustom bool                `json:"allow_custom"`
-	AllowEmpty  bool                `json:"allow_empty"`
+	Name           string              `json:"name"`
+	Description    string              `json:"description"`
+	Allowed        map[string]struct{} `json:"allowed"`
+	AllowCustom    bool                `json:"allow_custom"`
+	AllowEmpty     bool                `json:"allow_empty"`
+	MissingDefault string              `json:"missing_default,omitempty"`
+
+	AttributeInfo
 }
 
 func (a AttributeTypeEnum) isAttr() {}
@@ -288,26 +744,48 @@ func (a AttributeTypeEnum) GetName() string {
 
 func (a AttributeTypeEnum) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
-		Name          string              `json:"name"`
-		Description   string              `json:"description,omitempty"`
-		Allowed       map[string]struct{} `json:"allowed"`
-		AllowEmpty    bool                `json:"allow_empty"`
-		AllowCustom   bool                `json:"allow_custom"`
-		AttributeType string              `json:"attribute_type"`
+		Name           string              `json:"name"`
+		Description    string              `json:"description,omitempty"`
+		Allowed        map[string]struct{} `json:"allowed"`
+		AllowEmpty     bool                `json:"allow_empty"`
+		AllowCustom    bool                `json:"allow_custom"`
+		MissingDefault string              `json:"missing_default,omitempty"`
+		AttributeType  string              `json:"attribute_type"`
+
+		AttributeInfo
 	}{
-		Name:          a.Name,
-		Description:   a.Description,
-		Allowed:       a.Allowed,
-		AllowEmpty:    a.AllowEmpty,
-		AllowCustom:   a.AllowCustom,
-		AttributeType: "AttributeTypeEnum",
+		Name:           a.Name,
+		Description:    a.Description,
+		Allowed:        a.Allowed,
+		AllowEmpty:     a.AllowEmpty,
+		AllowCustom:    a.AllowCustom,
+		MissingDefault: a.MissingDefault,
+		AttributeType:  "AttributeTypeEnum",
+		AttributeInfo:  a.AttributeInfo,
 	})
 }
 
 // AttributeTypeSST allows for setting space-separated tokens values on an attribute.
+// Allowed field holds the tokens the spec defines, any token is allowed when it is empty.
+// AllowCustom field accounts for vocabularies that can be extended, it is left off for link types so that only the
+// ones listed by the spec are allowed.
+// Prefixes field holds the prefixes of tokens that are allowed whatever follows them, e.g. section- for autocomplete.
+// Pattern field holds a regular expression matching tokens that are allowed on top of Allowed, e.g. 16x16 for sizes.
+// Unique field accounts for sets where a token cannot be repeated and CaseSensitive field for sets whose tokens
+// are compared case-sensitively instead of ASCII case-insensitively.
+// Autofill field holds the grammar of autocomplete attributes, whose tokens have to be in a set order.
 type AttributeTypeSST struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name          string              `json:"name"`
+	Description   string              `json:"description"`
+	Allowed       map[string]struct{} `json:"allowed,omitempty"`
+	AllowCustom   bool                `json:"allow_custom,omitempty"`
+	Prefixes      []string            `json:"prefixes,omitempty"`
+	Pattern       string              `json:"pattern,omitempty"`
+	Unique        bool                `json:"unique,omitempty"`
+	CaseSensitive bool                `json:"case_sensitive,omitempty"`
+	Autofill      *AutofillGrammar    `json:"autofill,omitempty"`
+
+	AttributeInfo
 }
 
 func (a AttributeTypeSST) isAttr() {}
@@ -318,13 +796,30 @@ func (a AttributeTypeSST) GetName() string {
 
 func (a AttributeTypeSST) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
-		Name          string `json:"name"`
-		Description   string `json:"description,omitempty"`
-		AttributeType string `json:"attribute_type"`
+		Name          string              `json:"name"`
+		Description   string              `json:"description,omitempty"`
+		Allowed       map[string]struct{} `json:"allowed,omitempty"`
+		AllowCustom   bool                `json:"allow_custom,omitempty"`
+		Prefixes      []string            `json:"prefixes,omitempty"`
+		Pattern       string              `json:"pattern,omitempty"`
+		Unique        bool                `json:"unique,omitempty"`
+		CaseSensitive bool                `json:"case_sensitive,omitempty"`
+		Autofill      *AutofillGrammar    `json:"autofill,omitempty"`
+		AttributeType string              `json:"attribute_type"`
+
+		AttributeInfo
 	}{
 		Name:          a.Name,
 		Description:   a.Description,
+		Allowed:       a.Allowed,
+		AllowCustom:   a.AllowCustom,
+		Prefixes:      a.Prefixes,
+		Pattern:       a.Pattern,
+		Unique:        a.Unique,
+		CaseSensitive: a.CaseSensitive,
+		Autofill:      a.Autofill,
 		AttributeType: "AttributeTypeSST",
+		AttributeInfo: a.AttributeInfo,
 	})
 }
 
@@ -332,6 +827,8 @@ func (a AttributeTypeSST) MarshalJSON() ([]byte, error) {
 type AttributeTypePrefixedCustom struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
+
+	AttributeInfo
 }
 
 func (a AttributeTypePrefixedCustom) isAttr() {}
@@ -345,9 +842,12 @@ func (a AttributeTypePrefixedCustom) MarshalJSON() ([]byte, error) {
 		Name          string `json:"name"`
 		Description   string `json:"description,omitempty"`
 		AttributeType string `json:"attribute_type"`
+
+		AttributeInfo
 	}{
 		Name:          a.Name,
 		Description:   a.Description,
 		AttributeType: "AttributeTypePrefixedCustom",
+		AttributeInfo: a.AttributeInfo,
 	})
 }
@@ -0,0 +1,117 @@
+package spec
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Stability tells if browsers can be expected to support a feature, as annotated in the spec.
+type Stability string
+
+const (
+	// StabilityStable features are supported by at least two browser engines, which is the default.
+	StabilityStable Stability = ""
+	// StabilityLimited features are supported by fewer than two browser engines, which the spec's MDN annotations
+	// flag with a ⚠.
+	StabilityLimited Stability = "limited"
+	// StabilityInDevelopment features are marked as being in development, no browser may support them yet.
+	StabilityInDevelopment Stability = "in-development"
+)
+
+// rank orders stabilities from stable to in development.
+func (s Stability) rank() int {
+	switch s {
+	case StabilityLimited:
+		return 1
+	case StabilityInDevelopment:
+		return 2
+	}
+
+	return 0
+}
+
+// annotationStability returns the stability that an MDN annotation or status block flags, and if node is one.
+func annotationStability(node *html.Node) (Stability, bool) {
+	if !hasClass(node.Attr, "mdn-anno") && !hasClass(node.Attr, "status") {
+		return StabilityStable, false
+	}
+
+	if strings.Contains(strings.ToLower(rawText(node)), "in development") {
+		return StabilityInDevelopment, true
+	}
+
+	for n := range withDescendants(node) {
+		if n.Type == html.ElementNode && hasClass(n.Attr, "less-than-two-engines-flag") {
+			return StabilityLimited, true
+		}
+	}
+	if strings.Contains(rawText(node), "⚠") {
+		return StabilityLimited, true
+	}
+
+	return StabilityStable, true
+}
+
+// parseStabilities finds the annotated stability of the elements and attributes defined in node, keyed by the id
+// of their definition, e.g. the-selectedcontent-element or attr-input-alpha.
+// The annotations of an element follow its heading, while the annotations of an attribute precede the paragraph
+// or term that defines it.
+func parseStabilities(node *html.Node) map[string]Stability {
+	out := make(map[string]Stability)
+	set := func(id string, s Stability) {
+		if s.rank() > out[id].rank() {
+			out[id] = s
+		}
+	}
+
+	heading := ""
+	pending := StabilityStable
+	annotated := false
+	for n := range node.Descendants() {
+		if n.Type != html.ElementNode {
+			continue
+		}
+
+		if s, ok := annotationStability(n); ok {
+			if heading != "" {
+				set(heading, s)
+				continue
+			}
+
+			if !annotated || s.rank() > pending.rank() {
+				pending = s
+			}
+			annotated = true
+			continue
+		}
+
+		id, _ := getAttribute(n.Attr, "id")
+		switch {
+		case n.Data == "h4" && strings.HasPrefix(id, "the-") && strings.Contains(id, "-element"):
+			heading = id
+		case n.Data == "dl" && hasClass(n.Attr, "element"), n.Data == "p", n.Data == "h2", n.Data == "h3",
+			n.Data == "h4", n.Data == "h5":
+			heading = ""
+		}
+
+		if n.Data == "dfn" && annotated && heading == "" {
+			if strings.HasPrefix(id, "attr-") {
+				set(id, pending)
+			}
+			pending = StabilityStable
+			annotated = false
+		}
+	}
+
+	return out
+}
+
+// RemoveUnstable removes the elements and attributes that are not StabilityStable.
+func (sp *Spec) RemoveUnstable() {
+	sp.Filter(func(e *Element) bool {
+		return e.Stability == StabilityStable
+	}, func(attr Attribute) bool {
+		return attr.Info().Stability == StabilityStable
+	})
+}
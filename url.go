@@ -0,0 +1,69 @@
+package spec
+
+import (
+	"net/url"
+	"regexp"
+)
+
+// markdownLink matches the targets of Markdown links that are not escaped, e.g. the #the-a-element of
+// [`a`](#the-a-element).
+var markdownLink = regexp.MustCompile(`(^|[^\\])\]\(([^)\s]+)\)`)
+
+// ResolveURLs resolves the links of the elements and attributes, as well as the links in element descriptions,
+// against base, e.g. #the-a-element becomes https://html.spec.whatwg.org/#the-a-element for the single page spec.
+// Links that are already absolute are left as is.
+func (sp *Spec) ResolveURLs(base string) error {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return err
+	}
+
+	resolve := func(link string) (string, error) {
+		if link == "" {
+			return "", nil
+		}
+
+		ref, err := url.Parse(link)
+		if err != nil {
+			return "", err
+		}
+
+		return baseURL.ResolveReference(ref).String(), nil
+	}
+
+	resolveAttributes := func(attrs []Attribute) error {
+		for _, attr := range attrs {
+			info := attr.Info()
+			if info.URL, err = resolve(info.URL); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err = resolveAttributes(sp.Attributes); err != nil {
+		return err
+	}
+
+	for _, e := range sp.Elements {
+		if e.URL, err = resolve(e.URL); err != nil {
+			return err
+		}
+		if err = resolveAttributes(e.Attributes); err != nil {
+			return err
+		}
+
+		e.Description = markdownLink.ReplaceAllStringFunc(e.Description, func(match string) string {
+			parts := markdownLink.FindStringSubmatch(match)
+			link, err := resolve(parts[2])
+			if err != nil {
+				return match
+			}
+
+			return parts[1] + "](" + link + ")"
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package spec
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// valueIndex holds the rows of the index tables of attributes, keyed by attribute name and then by element tag,
+// with global attributes under the empty tag.
+type valueIndex map[string]map[string]indexedAttribute
+
+// indexedAttribute is an attribute's entry in the index tables of attributes for a single element.
+type indexedAttribute struct {
+	// value is the text of the "Value" column.
+	value string
+	// href links to the attribute's definition on the element, e.g. #attr-hyperlink-href.
+	href string
+}
+
+// parseAttributeTable reads the rows of one of the index tables of attributes into idx.
+// Each row holds the attribute name, the elements it applies to, a description and its value.
+func (idx valueIndex) parseAttributeTable(table *html.Node) {
+	for tr := range table.Descendants() {
+		if tr.Type != html.ElementNode || tr.Data != "tr" {
+			continue
+		}
+
+		var cells []*html.Node
+		for cell := range tr.ChildNodes() {
+			if cell.Type == html.ElementNode && (cell.Data == "th" || cell.Data == "td") {
+				cells = append(cells, cell)
+			}
+		}
+		if len(cells) < 4 || cells[0].Data != "th" {
+			continue
+		}
+
+		tags := codeSpans(gatherCodeText(cells[1]))
+		if strings.Contains(rawText(cells[1]), "HTML elements") {
+			tags = append(tags, "")
+		}
+		if len(tags) == 0 {
+			continue
+		}
+
+		hrefs := make(map[string]string)
+		for a := range cells[1].Descendants() {
+			if a.Type != html.ElementNode || a.Data != "a" {
+				continue
+			}
+			href, _ := getAttribute(a.Attr, "href")
+			if strings.Contains(rawText(a), "HTML elements") {
+				hrefs[""] = href
+			} else {
+				hrefs[strings.TrimSpace(rawText(a))] = href
+			}
+		}
+
+		name := strings.TrimSpace(rawText(cells[0]))
+		if idx[name] == nil {
+			idx[name] = make(map[string]indexedAttribute)
+		}
+		for _, tag := range tags {
+			idx[name][tag] = indexedAttribute{
+				value: strings.Join(strings.Fields(rawText(cells[3])), " "),
+				href:  hrefs[tag],
+			}
+		}
+	}
+}
+
+// value returns the value column of the attribute with the given name on the element with the given tag,
+// falling back to the row for the global attribute of that name.
+func (idx valueIndex) value(tag, name string) (string, bool) {
+	attr, ok := idx.lookup(tag, name)
+	return attr.value, ok
+}
+
+// href returns the link to the definition of the attribute with the given name on the element with the given tag,
+// falling back to the row for the global attribute of that name like value.
+func (idx valueIndex) href(tag, name string) string {
+	attr, _ := idx.lookup(tag, name)
+	return attr.href
+}
+
+func (idx valueIndex) lookup(tag, name string) (indexedAttribute, bool) {
+	if attr, ok := idx[name][tag]; ok {
+		return attr, true
+	}
+
+	attr, ok := idx[name][""]
+	return attr, ok
+}
@@ -0,0 +1,212 @@
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+)
+
+// iconSizesPattern matches the WxH tokens of the sizes attribute of link.
+const iconSizesPattern = `^[1-9][0-9]*[xX][1-9][0-9]*$`
+
+// sandboxKeywords are used for the sandbox attribute of iframe when the spec's keywords could not be found.
+var sandboxKeywords = []string{
+	"allow-downloads",
+	"allow-forms",
+	"allow-modals",
+	"allow-orientation-lock",
+	"allow-pointer-lock",
+	"allow-popups",
+	"allow-popups-to-escape-sandbox",
+	"allow-presentation",
+	"allow-same-origin",
+	"allow-scripts",
+	"allow-top-navigation",
+	"allow-top-navigation-by-user-activation",
+	"allow-top-navigation-to-custom-protocols",
+}
+
+var vocabularyToken = regexp.MustCompile(`^[a-z0-9-]+\*?$`)
+
+// vocabularies holds the token vocabularies of space-separated token attributes read from the spec.
+type vocabularies struct {
+	linkTypes []*LinkType
+	sandbox   map[string]struct{}
+	autofill  map[string]struct{}
+	// autofillPrefixes holds the autofill tokens that are written with a trailing *, such as section-*.
+	autofillPrefixes []string
+}
+
+func newVocabularies() *vocabularies {
+	return &vocabularies{
+		sandbox:  make(map[string]struct{}),
+		autofill: make(map[string]struct{}),
+	}
+}
+
+// collectKeywords finds the sandbox keywords and autofill tokens in node, which the spec marks up with
+// data-x attributes such as attr-iframe-sandbox-allow-forms and attr-fe-autocomplete-email.
+func (v *vocabularies) collectKeywords(node *html.Node) {
+	for n := range node.Descendants() {
+		if n.Type != html.ElementNode {
+			continue
+		}
+
+		dataX, ok := getAttribute(n.Attr, "data-x")
+		if !ok {
+			continue
+		}
+
+		token := strings.ToLower(strings.TrimSpace(rawText(n)))
+		if !vocabularyToken.MatchString(token) {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(dataX, "attr-iframe-sandbox-allow-"):
+			v.sandbox[token] = struct{}{}
+		case strings.HasPrefix(dataX, "attr-fe-autocomplete-"):
+			if prefix, ok := strings.CutSuffix(token, "*"); ok {
+				if !slices.Contains(v.autofillPrefixes, prefix) {
+					v.autofillPrefixes = append(v.autofillPrefixes, prefix)
+				}
+				continue
+			}
+			v.autofill[token] = struct{}{}
+		}
+	}
+}
+
+// apply sets the vocabulary of attr, an attribute of the element with the given tag, if it has one.
+func (v *vocabularies) apply(tag string, attr Attribute) {
+	sst, ok := attr.(*AttributeTypeSST)
+	if !ok {
+		return
+	}
+
+	switch sst.Name {
+	case "rel":
+		keywords := make(map[string]struct{})
+		for _, lt := range v.linkTypes {
+			if lt.AllowedOn(tag) {
+				keywords[lt.Keyword] = struct{}{}
+			}
+		}
+		// Link types registered outside the spec are not known, so only the ones it lists are allowed.
+		if len(keywords) > 0 {
+			sst.Allowed = keywords
+		}
+	case "sandbox":
+		sst.Allowed = maps.Clone(v.sandbox)
+		if len(sst.Allowed) == 0 {
+			sst.Allowed = tokenSet(sandboxKeywords...)
+		}
+	case "autocomplete":
+		if len(v.autofill) > 0 {
+			sst.Allowed = maps.Clone(v.autofill)
+			sst.Prefixes = slices.Clone(v.autofillPrefixes)
+		}
+		sst.Autofill = newAutofillGrammar(v.autofill, v.autofillPrefixes)
+	}
+}
+
+// applyTokenConstraints sets the uniqueness and case-sensitivity of a space-separated token attribute from the text
+// of its "Value" column in the attribute index, such as "Unordered set of unique space-separated tokens,
+// ASCII case-insensitive".
+func applyTokenConstraints(attr Attribute, value string) {
+	sst, ok := attr.(*AttributeTypeSST)
+	if !ok {
+		return
+	}
+
+	value = strings.ToLower(value)
+	sst.Unique = sst.Unique || strings.Contains(value, "unique")
+	sst.CaseSensitive = sst.CaseSensitive || (strings.Contains(value, "case-sensitive") &&
+		!strings.Contains(value, "case-insensitive"))
+}
+
+// CheckValue checks that the tokens of value are allowed by the attribute's vocabulary and are not repeated
+// when the set is unique. Autocomplete values are checked against their autofill grammar instead.
+func (a AttributeTypeSST) CheckValue(value string) error {
+	if a.Autofill != nil {
+		if err := a.Autofill.Validate(value); err != nil {
+			return fmt.Errorf("attribute %q: %w", a.Name, err)
+		}
+		return nil
+	}
+
+	var pattern *regexp.Regexp
+	if a.Pattern != "" {
+		var err error
+		if pattern, err = compilePattern(a.Pattern); err != nil {
+			return fmt.Errorf("attribute %q: invalid pattern: %w", a.Name, err)
+		}
+	}
+
+	seen := make(map[string]struct{})
+	for _, token := range strings.Fields(value) {
+		key := token
+		if !a.CaseSensitive {
+			key = strings.ToLower(token)
+		}
+
+		if _, ok := seen[key]; ok && a.Unique {
+			return fmt.Errorf("attribute %q: token %q is repeated", a.Name, token)
+		}
+		seen[key] = struct{}{}
+
+		if !a.allowsToken(key, pattern) {
+			return fmt.Errorf("attribute %q: token %q is not allowed", a.Name, token)
+		}
+	}
+
+	return nil
+}
+
+func (a AttributeTypeSST) allowsToken(token string, pattern *regexp.Regexp) bool {
+	if a.AllowCustom || (len(a.Allowed) == 0 && len(a.Prefixes) == 0 && pattern == nil) {
+		return true
+	}
+	if _, ok := a.Allowed[token]; ok {
+		return true
+	}
+	for _, prefix := range a.Prefixes {
+		if len(token) > len(prefix) && strings.HasPrefix(token, prefix) {
+			return true
+		}
+	}
+
+	return pattern != nil && pattern.MatchString(token)
+}
+
+// patterns caches the compiled token patterns of AttributeTypeSST, which are checked for every value.
+var patterns sync.Map
+
+// compilePattern returns the compiled form of pattern, compiling it on first use.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(pattern, re)
+
+	return re, nil
+}
+
+func tokenSet(tokens ...string) map[string]struct{} {
+	out := make(map[string]struct{}, len(tokens))
+	for _, token := range tokens {
+		out[token] = struct{}{}
+	}
+
+	return out
+}
@@ -0,0 +1,38 @@
+package spec
+
+import "testing"
+
+func TestAttributeTypeSST_CheckValue(t *testing.T) {
+	rel := AttributeTypeSST{Name: "rel", Allowed: tokenSet("icon", "stylesheet"), Unique: true}
+	sizes := AttributeTypeSST{Name: "sizes", Allowed: tokenSet("any"), Pattern: iconSizesPattern, Unique: true}
+	autocomplete := AttributeTypeSST{Name: "autocomplete", Allowed: tokenSet("email", "shipping"), Prefixes: []string{"section-"}}
+	class := AttributeTypeSST{Name: "class", CaseSensitive: true}
+	itemref := AttributeTypeSST{Name: "itemref", Unique: true, CaseSensitive: true}
+
+	tests := []struct {
+		name    string
+		attr    AttributeTypeSST
+		value   string
+		wantErr bool
+	}{
+		{name: "allowed tokens", attr: rel, value: "icon stylesheet"},
+		{name: "case-insensitive", attr: rel, value: "ICON"},
+		{name: "unknown token", attr: rel, value: "icon foo", wantErr: true},
+		{name: "repeated token", attr: rel, value: "icon Icon", wantErr: true},
+		{name: "custom tokens", attr: AttributeTypeSST{Name: "rel", Allowed: tokenSet("icon"), AllowCustom: true}, value: "foo"},
+		{name: "pattern", attr: sizes, value: "16x16 32X32 any"},
+		{name: "pattern mismatch", attr: sizes, value: "0x16", wantErr: true},
+		{name: "prefix", attr: autocomplete, value: "section-blue shipping email"},
+		{name: "bare prefix", attr: autocomplete, value: "section-", wantErr: true},
+		{name: "open vocabulary", attr: class, value: "a A a"},
+		{name: "case-sensitive unique", attr: itemref, value: "a A"},
+		{name: "case-sensitive repeated", attr: itemref, value: "a a", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.attr.CheckValue(tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("CheckValue(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package spec
+
+// Warning is a problem found while generating a spec that did not stop the generation, such as an element that
+// the spec's index lists but that was not found in its own section.
+type Warning struct {
+	Message string `json:"message"`
+	// Position is where the problem is in the source document, it is left empty when that is not known.
+	Position Position `json:"position,omitzero"`
+}
+
+func (w Warning) String() string {
+	if w.Position.Line == 0 {
+		return w.Message
+	}
+
+	return w.Position.String() + ": " + w.Message
+}